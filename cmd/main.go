@@ -17,13 +17,30 @@ import (
 	"github.com/vektah/gqlparser/v2/ast"
 
 	"github.com/trust-me-im-an-engineer/mini-reddit/graph"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/config"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/loader"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/markdown"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/querylimit"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/ban"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/comment"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/idempotency"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/modlog"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/post"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/preview"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/ratelimit"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/report"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/role"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/saved"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/search"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/subscription"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/visibility"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage/inmemory"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage/postgres"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/unfurl"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/validator"
 )
 
 func main() {
@@ -62,14 +79,64 @@ func main() {
 	}
 
 	// --- Services and GraphQL Resolver Setup ---
+	roleService := role.NewService(storage, cfg.Admins)
+	banService := ban.NewService(storage, roleService)
+	idempotencyService := idempotency.NewService(storage, cfg.Idempotency.TTL, cfg.Idempotency.InProgressTimeout)
+	postService := post.NewService(storage, roleService, banService, idempotencyService, cfg.Retention.DeletedPosts, cfg.DuplicateLinkWindow)
+	commentService := comment.NewService(storage, roleService, banService, idempotencyService)
+	rateLimitService := ratelimit.NewService(storage, map[ratelimit.Action]domain.RateLimit{
+		ratelimit.ActionCreatePost:    {Interval: cfg.RateLimit.CreatePostInterval, Burst: cfg.RateLimit.CreatePostBurst},
+		ratelimit.ActionCreateComment: {Interval: cfg.RateLimit.CreateCommentInterval, Burst: cfg.RateLimit.CreateCommentBurst},
+		ratelimit.ActionVote:          {Interval: cfg.RateLimit.VoteInterval, Burst: cfg.RateLimit.VoteBurst},
+	})
+	savedService := saved.NewService(storage)
+	previewService := preview.NewService(storage, unfurl.NewHTTPFetcher(cfg.Preview.Timeout, cfg.Preview.MaxBytes), cfg.Preview.TTL, cfg.Preview.Workers)
+	limits := validator.Limits{
+		MaxTitleLen:        cfg.Limits.MaxTitleLen,
+		MaxContentLen:      cfg.Limits.MaxContentLen,
+		MaxCommentLen:      cfg.Limits.MaxCommentLen,
+		MaxReportLen:       cfg.Limits.MaxReportLen,
+		MaxPostsPerPage:    cfg.Limits.MaxPostsPerPage,
+		MaxCommentsPerPage: cfg.Limits.MaxCommentsPerPage,
+		MaxReportsPerPage:  cfg.Limits.MaxReportsPerPage,
+		MaxModLogPerPage:   cfg.Limits.MaxModLogPerPage,
+		MaxCommentDepth:    cfg.Limits.MaxCommentDepth,
+	}
+	inputValidator := validator.New(validator.TextPolicy{
+		CountGraphemes:  cfg.Text.LengthUnit == "GRAPHEME",
+		NormalizeNFC:    cfg.Text.NormalizeNFC,
+		TrimSpace:       cfg.Text.TrimSpace,
+		RejectInvisible: cfg.Text.RejectInvisible,
+		RejectControl:   cfg.Text.RejectControl,
+	}, limits)
+	markdownRenderer, err := markdown.New(cfg.MarkdownCacheSize)
+	if err != nil {
+		slog.Error("failed to initialize markdown renderer", "error", err)
+		os.Exit(1)
+	}
 	resolver := graph.NewResolver(
-		post.NewService(storage),
-		comment.NewService(storage),
+		postService,
+		commentService,
 		subscription.NewService(),
+		rateLimitService,
+		roleService,
+		report.NewService(storage, roleService),
+		modlog.NewService(storage, roleService),
+		banService,
+		search.NewService(storage),
+		savedService,
+		visibility.NewService(storage),
+		previewService,
+		inputValidator,
+		markdownRenderer,
 	)
 
 	// --- HTTP Server Setup ---
-	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers:  resolver,
+		Complexity: graph.NewComplexityRoot(limits),
+	}))
+	srv.SetErrorPresenter(graph.ErrorPresenter)
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
@@ -78,9 +145,11 @@ func main() {
 	srv.Use(extension.AutomaticPersistedQuery{
 		Cache: lru.New[string](cfg.Graphql.AutomaticPersistedQuery),
 	})
+	srv.Use(extension.FixedComplexityLimit(cfg.Graphql.MaxComplexity))
+	srv.Use(querylimit.DepthLimit{Limit: cfg.Graphql.MaxDepth})
 
 	router := http.NewServeMux()
-	router.Handle("/query", srv)
+	router.Handle("/query", auth.Middleware(cfg.TrustedProxies)(loader.Middleware(postService, commentService, savedService, previewService)(srv)))
 
 	if cfg.Graphql.Playground {
 		router.Handle("/playground", playground.Handler("GraphQL playground", "/query"))
@@ -92,6 +161,14 @@ func main() {
 		Handler: router,
 	}
 
+	// --- Background Jobs ---
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go postService.RunPurge(jobsCtx, cfg.Retention.PurgeInterval)
+	go idempotencyService.RunPurge(jobsCtx, cfg.Idempotency.PurgeInterval)
+	go previewService.Run(jobsCtx, cfg.Preview.Interval)
+	go rateLimitService.RunPurge(jobsCtx, cfg.RateLimit.PurgeInterval)
+
 	// --- Signal Handling Channel ---
 	stopCh := make(chan os.Signal, 1)
 	// Notify the stopCh for interrupt (Ctrl+C) and termination signals
@@ -121,6 +198,8 @@ func main() {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
+	stopJobs()
+
 	// --- Shut Down HTTP Server ---
 	if err := httpServer.Shutdown(shutdownCtx); err != nil {
 		slog.Error("http server shutdown failed", "error", err)
@@ -0,0 +1,59 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/loader"
+)
+
+// getComments batches requests for first pages through loader, so that expanding comments of
+// every node on a page costs single query, and sends requests with cursor to comment service directly.
+func (r *Resolver) getComments(ctx context.Context, q *domain.CommentsInput) (*domain.CommentConnection, error) {
+	if q.Cursor != nil {
+		return r.commentService.GetComments(ctx, q)
+	}
+
+	key := loader.CommentsKey{
+		PostID: q.PostID,
+		Sort:   q.Sort,
+		Limit:  q.Limit,
+	}
+	if q.ParentID != nil {
+		key.ParentID = *q.ParentID
+	}
+	return r.loaders(ctx).Comments.Load(ctx, key)()
+}
+
+// commentsDepthLeft returns how many comment levels current comments field may still expand.
+// Each enclosing Post.comments or Comment.children field limits nesting with its own depth argument,
+// so the strictest of them wins.
+func commentsDepthLeft(ctx context.Context, depth int32) int32 {
+	left := depth
+	var level int32
+	for fc := graphql.GetFieldContext(ctx).Parent; fc != nil; fc = fc.Parent {
+		if !isCommentsField(fc) {
+			continue
+		}
+		level++
+		if d, ok := fc.Args["depth"].(int32); ok && d-level < left {
+			left = d - level
+		}
+	}
+	return left
+}
+
+func isCommentsField(fc *graphql.FieldContext) bool {
+	return fc.Object == "Post" && fc.Field.Name == "comments" ||
+		fc.Object == "Comment" && fc.Field.Name == "children"
+}
+
+func emptyCommentConnection() *model.CommentConnection {
+	return &model.CommentConnection{
+		Edges:    []*model.CommentEdge{},
+		PageInfo: &model.PageInfo{HasNextPage: false},
+	}
+}
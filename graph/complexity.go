@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/validator"
+)
+
+// NewComplexityRoot weights list fields by the number of items they may return,
+// every other field costs 1 plus complexity of its selection.
+// Arguments are clamped to limits, so that out of range values which validator rejects later
+// can neither lower complexity of the whole query nor overflow it.
+func NewComplexityRoot(limits validator.Limits) ComplexityRoot {
+	var c ComplexityRoot
+
+	postsPage := func(limit int32) int { return pageSize(limit, limits.MaxPostsPerPage) }
+	commentsPage := func(limit int32) int { return pageSize(limit, limits.MaxCommentsPerPage) }
+	// Search and saved items page by comments limit when listing comments, the same way validator does
+	itemsPage := func(comments bool, limit int32) int {
+		if comments {
+			return commentsPage(limit)
+		}
+		return postsPage(limit)
+	}
+
+	c.Query.Posts = func(childComplexity int, sort model.SortOrder, limit int32, cursor *string) int {
+		return 1 + postsPage(limit)*childComplexity
+	}
+	c.Query.PostsByDomain = func(childComplexity int, domain string, sort model.SortOrder, limit int32, cursor *string) int {
+		return 1 + postsPage(limit)*childComplexity
+	}
+	c.Query.ModerationQueue = func(childComplexity int, community string, status model.ReportStatus, limit int32, cursor *string) int {
+		return 1 + pageSize(limit, limits.MaxReportsPerPage)*childComplexity
+	}
+	c.Query.ModLog = func(childComplexity int, community *string, actorID *uuid.UUID, action *model.ModLogAction, limit int32, cursor *string) int {
+		return 1 + pageSize(limit, limits.MaxModLogPerPage)*childComplexity
+	}
+	c.Query.Search = func(childComplexity int, query string, typeArg model.SearchType, sort model.SearchSort, limit int32, cursor *string) int {
+		return 1 + itemsPage(typeArg == model.SearchTypeComment, limit)*childComplexity
+	}
+	c.Query.Saved = func(childComplexity int, typeArg model.SavedType, limit int32, cursor *string) int {
+		return 1 + itemsPage(typeArg == model.SavedTypeComment, limit)*childComplexity
+	}
+	c.Post.Comments = func(childComplexity int, sort model.SortOrder, limit int32, cursor *string, depth int32) int {
+		return commentsComplexity(childComplexity, commentsPage(limit), depth)
+	}
+	c.Comment.Children = func(childComplexity int, sort model.SortOrder, limit int32, cursor *string, depth int32) int {
+		return commentsComplexity(childComplexity, commentsPage(limit), depth)
+	}
+	c.Comment.ParentTree = func(childComplexity int, depth *int32) int {
+		maxDepth := int32(1)
+		if depth != nil {
+			maxDepth = *depth
+		}
+		return 1 + pageSize(maxDepth, limits.MaxCommentDepth)*childComplexity
+	}
+
+	return c
+}
+
+// pageSize clamps requested number of items to [0, maxSize].
+func pageSize(limit, maxSize int32) int {
+	return int(min(max(limit, 0), maxSize))
+}
+
+// commentsComplexity charges every comment on a page with its selection, which already includes
+// nested children fields. Non-positive depth does not expand anything, so it costs only the field itself.
+func commentsComplexity(childComplexity, pageSize int, depth int32) int {
+	if depth <= 0 {
+		return 1
+	}
+	return 1 + pageSize*childComplexity
+}
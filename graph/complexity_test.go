@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/validator"
+)
+
+const testMaxComplexity = 5000
+
+var testLimits = validator.Limits{
+	MaxPostsPerPage:    100,
+	MaxCommentsPerPage: 100,
+	MaxReportsPerPage:  50,
+	MaxModLogPerPage:   20,
+	MaxCommentDepth:    10,
+}
+
+// newComplexityTestServer serves schema without services, so only queries rejected before execution may be sent to it.
+func newComplexityTestServer() http.Handler {
+	srv := handler.New(NewExecutableSchema(Config{
+		Resolvers:  &Resolver{},
+		Complexity: NewComplexityRoot(testLimits),
+	}))
+	srv.SetErrorPresenter(ErrorPresenter)
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.FixedComplexityLimit(testMaxComplexity))
+	return srv
+}
+
+func TestComplexityLimitCannotBeBypassed(t *testing.T) {
+	const expensive = `expensive: posts(limit: 100) { edges { node { id comments(limit: 100) { edges { node { id } } } } } }`
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{
+			name:  "expensive field alone",
+			query: `{ ` + expensive + ` }`,
+		},
+		{
+			name:  "negative limit next to expensive field",
+			query: `{ cheap: posts(limit: -1000000000) { edges { node { id } } } ` + expensive + ` }`,
+		},
+		{
+			name:  "negative comments limit next to expensive field",
+			query: `{ cheap: posts(limit: 100) { edges { node { comments(limit: -2147483648) { edges { node { id } } } } } } ` + expensive + ` }`,
+		},
+		{
+			name:  "huge limits",
+			query: `{ posts(limit: 2147483647) { edges { node { comments(limit: 2147483647) { edges { node { children(limit: 2147483647) { edges { node { id } } } } } } } } } }`,
+		},
+	}
+
+	srv := newComplexityTestServer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"query": tt.query})
+			req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			var resp struct {
+				Errors []struct {
+					Message    string         `json:"message"`
+					Extensions map[string]any `json:"extensions"`
+				} `json:"errors"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+			}
+			if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "COMPLEXITY_LIMIT_EXCEEDED" {
+				t.Fatalf("want single COMPLEXITY_LIMIT_EXCEEDED error, got %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestComplexityRootClampsLimits(t *testing.T) {
+	c := NewComplexityRoot(testLimits)
+	const child = 10
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"negative posts limit", c.Query.Posts(child, model.SortOrderNew, -1000000, nil), 1},
+		{"huge posts limit", c.Query.Posts(child, model.SortOrderNew, 2147483647, nil), 1 + 100*child},
+		{"negative comments limit", c.Post.Comments(child, model.SortOrderNew, -1, nil, 2), 1},
+		{"huge search limit", c.Query.Search(child, "go", model.SearchTypeComment, model.SearchSortRelevance, 2147483647, nil), 1 + 100*child},
+		{"huge moderation queue limit", c.Query.ModerationQueue(child, "golang", model.ReportStatusOpen, 2147483647, nil), 1 + 50*child},
+		{"huge mod log limit", c.Query.ModLog(child, ptr("golang"), nil, nil, 2147483647, nil), 1 + 20*child},
+		{"huge parent tree depth", c.Comment.ParentTree(child, ptr(int32(2147483647))), 1 + 10*child},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: complexity = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestPageSize(t *testing.T) {
+	tests := []struct {
+		limit, maxSize int32
+		want           int
+	}{
+		{limit: -1, maxSize: 100, want: 0},
+		{limit: 0, maxSize: 100, want: 0},
+		{limit: 42, maxSize: 100, want: 42},
+		{limit: 2147483647, maxSize: 100, want: 100},
+	}
+	for _, tt := range tests {
+		if got := pageSize(tt.limit, tt.maxSize); got != tt.want {
+			t.Errorf("pageSize(%d, %d) = %d, want %d", tt.limit, tt.maxSize, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+// extendedError is implemented by errors carrying machine-readable details for clients,
+// such as errs.Error with its code.
+type extendedError interface {
+	Extensions() map[string]any
+}
+
+// ErrorPresenter adds extensions of errors implementing extendedError to response,
+// so that clients can match on extensions.code instead of messages.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var extended extendedError
+	if errors.As(err, &extended) {
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = make(map[string]any)
+		}
+		for k, v := range extended.Extensions() {
+			gqlErr.Extensions[k] = v
+		}
+	}
+	return gqlErr
+}
+
+// rateLimitError hides failures of rate limit storage behind internal server error.
+func rateLimitError(err error) error {
+	if err := errs.Exposable(err); err != nil {
+		return err
+	}
+	slog.Error("rate limit service failed", "error", err)
+	return errs.InternalServer
+}
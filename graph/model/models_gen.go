@@ -12,17 +12,65 @@ import (
 	"github.com/google/uuid"
 )
 
+// Banned user cannot post, comment or vote in community, or anywhere when ban is global.
+type Ban struct {
+	// Null for global ban.
+	Community *string   `json:"community,omitempty"`
+	UserID    uuid.UUID `json:"userID"`
+	Reason    *string   `json:"reason,omitempty"`
+	BannedBy  uuid.UUID `json:"bannedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Null for permanent ban.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type BanInput struct {
+	UserID uuid.UUID `json:"userID"`
+	// Global ban when omitted, available to admins only.
+	Community *string    `json:"community,omitempty"`
+	Reason    *string    `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type BlockedUser struct {
+	UserID    uuid.UUID `json:"userID"`
+	BlockedAt time.Time `json:"blockedAt"`
+}
+
 type Comment struct {
-	ID         string             `json:"id"`
-	PostID     string             `json:"postID"`
-	AuthorID   uuid.UUID          `json:"authorID"`
-	Text       string             `json:"text"`
-	CreatedAt  time.Time          `json:"createdAt"`
-	Rating     int32              `json:"rating"`
-	Deleted    bool               `json:"deleted"`
-	ParentID   *string            `json:"parentID,omitempty"`
-	Children   *CommentConnection `json:"children"`
-	ParentTree []*Comment         `json:"parentTree"`
+	ID       string    `json:"id"`
+	PostID   string    `json:"postID"`
+	AuthorID uuid.UUID `json:"authorID"`
+	Text     string    `json:"text"`
+	// Text rendered to sanitized HTML the same way as contentHTML of post.
+	TextHTML  string    `json:"textHTML"`
+	CreatedAt time.Time `json:"createdAt"`
+	Rating    int32     `json:"rating"`
+	Upvotes   int32     `json:"upvotes"`
+	Downvotes int32     `json:"downvotes"`
+	// Vote of the current user: 1 or -1, null if not voted or anonymous.
+	MyVote *int32 `json:"myVote,omitempty"`
+	// Whether the current user saved comment, false for anonymous user.
+	Saved bool `json:"saved"`
+	// Text of deleted comment is replaced with [deleted] placeholder.
+	Deleted bool `json:"deleted"`
+	// Text of removed comment is replaced with [removed by moderator] placeholder.
+	Removed       bool       `json:"removed"`
+	RemovedBy     *uuid.UUID `json:"removedBy,omitempty"`
+	RemovedReason *string    `json:"removedReason,omitempty"`
+	RemovedAt     *time.Time `json:"removedAt,omitempty"`
+	// Time of the last edit, null if comment was never edited.
+	EditedAt *time.Time `json:"editedAt,omitempty"`
+	// Starts from 1 and grows with every edit, matches number of the current version among revisions.
+	Version int32 `json:"version"`
+	// Versions of comment replaced by edits, oldest first. Available to author and moderators of community.
+	Revisions []*CommentRevision `json:"revisions,omitempty"`
+	// Unified diff between versions of comment, numbered as revisions with the current version last.
+	// Diff goes to the current version when to is omitted. Available to author and moderators of community.
+	RevisionDiff *string            `json:"revisionDiff,omitempty"`
+	ParentID     *string            `json:"parentID,omitempty"`
+	Children     *CommentConnection `json:"children"`
+	ParentTree   []*Comment         `json:"parentTree"`
 }
 
 type CommentConnection struct {
@@ -35,17 +83,79 @@ type CommentEdge struct {
 	Node   *Comment `json:"node"`
 }
 
+// Version of comment replaced by edit.
+type CommentRevision struct {
+	// Revisions are numbered from 1 in order of edits.
+	Number   int32     `json:"number"`
+	Text     string    `json:"text"`
+	EditorID uuid.UUID `json:"editorID"`
+	// When version was replaced.
+	EditedAt time.Time `json:"editedAt"`
+}
+
 type CreateCommentInput struct {
-	PostID   string    `json:"postID"`
+	PostID string `json:"postID"`
+	// Must be id of authenticated user.
 	AuthorID uuid.UUID `json:"authorID"`
 	Text     string    `json:"text"`
 	ParentID *string   `json:"parentID,omitempty"`
+	// Same as idempotencyKey of createPost input.
+	IdempotencyKey *string `json:"idempotencyKey,omitempty"`
 }
 
 type CreatePostInput struct {
-	AuthorID uuid.UUID `json:"authorID"`
-	Title    string    `json:"title"`
-	Content  string    `json:"content"`
+	// Must be id of authenticated user.
+	AuthorID  uuid.UUID `json:"authorID"`
+	Community string    `json:"community"`
+	Kind      PostKind  `json:"kind"`
+	Title     string    `json:"title"`
+	// Required for text posts.
+	Content string `json:"content"`
+	// Absolute http or https url, required for link posts and not allowed for text posts.
+	URL *string `json:"url,omitempty"`
+	// Retrying request with the same key returns post created by the first request instead of creating another one.
+	// Keys are kept per author for limited time, reusing key with different input fails with IDEMPOTENCY_KEY_REUSED error.
+	IdempotencyKey *string `json:"idempotencyKey,omitempty"`
+}
+
+type LinkPreview struct {
+	Title       *string   `json:"title,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	ImageURL    *string   `json:"imageURL,omitempty"`
+	FetchedAt   time.Time `json:"fetchedAt"`
+}
+
+type ModLogConnection struct {
+	Edges    []*ModLogEdge `json:"edges"`
+	PageInfo *PageInfo     `json:"pageInfo"`
+}
+
+type ModLogEdge struct {
+	Cursor string       `json:"cursor"`
+	Node   *ModLogEntry `json:"node"`
+}
+
+// Moderation or administrative action. Actions of authors on own content are not logged.
+type ModLogEntry struct {
+	ID string `json:"id"`
+	// Null for global actions of admins.
+	Community  *string          `json:"community,omitempty"`
+	ActorID    uuid.UUID        `json:"actorID"`
+	Action     ModLogAction     `json:"action"`
+	TargetType ModLogTargetType `json:"targetType"`
+	// Post or comment id, or user id.
+	TargetID string `json:"targetID"`
+	// JSON of target state before action.
+	Before *string `json:"before,omitempty"`
+	// JSON of target state after action.
+	After     *string   `json:"after,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Moderator struct {
+	Community string    `json:"community"`
+	UserID    uuid.UUID `json:"userID"`
+	CreatedAt time.Time `json:"createdAt"`
 }
 
 type Mutation struct {
@@ -57,15 +167,55 @@ type PageInfo struct {
 }
 
 type Post struct {
-	ID                 string             `json:"id"`
-	AuthorID           uuid.UUID          `json:"authorID"`
-	Title              string             `json:"title"`
-	Content            string             `json:"content"`
-	CreatedAt          time.Time          `json:"createdAt"`
-	Rating             int32              `json:"rating"`
-	CommentsCount      int32              `json:"commentsCount"`
-	CommentsRestricted bool               `json:"commentsRestricted"`
-	Comments           *CommentConnection `json:"comments"`
+	ID        string    `json:"id"`
+	AuthorID  uuid.UUID `json:"authorID"`
+	Community string    `json:"community"`
+	Title     string    `json:"title"`
+	// Optional for link posts, empty if omitted.
+	Content string `json:"content"`
+	// Content rendered from markdown to sanitized HTML: paragraphs, emphasis, links, code, quotes, lists and >!spoilers!<.
+	// Raw HTML is escaped.
+	ContentHTML string   `json:"contentHTML"`
+	Kind        PostKind `json:"kind"`
+	// Link of link post, null for text posts and deleted or removed link posts.
+	URL *string `json:"url,omitempty"`
+	// Host of url without www prefix, e.g. go.dev.
+	Domain *string `json:"domain,omitempty"`
+	// Other posts of the same link in community made recently, newest first, deleted and removed posts are skipped.
+	// Links to the same page match regardless of scheme, www prefix, fragment, trailing slash, tracking parameters and order of query.
+	// Clients select it on createPost to warn author about duplicate, it is empty for text posts.
+	DuplicateLinks []*Post `json:"duplicateLinks"`
+	// Title, description and thumbnail of linked page taken from its OpenGraph and HTML meta tags.
+	// Previews are fetched in background, it is null until fetched, for text posts and when page could not be fetched.
+	Preview   *LinkPreview `json:"preview,omitempty"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Rating    int32        `json:"rating"`
+	Upvotes   int32        `json:"upvotes"`
+	Downvotes int32        `json:"downvotes"`
+	// Vote of the current user: 1 or -1, null if not voted or anonymous.
+	MyVote *int32 `json:"myVote,omitempty"`
+	// Whether the current user saved post, false for anonymous user.
+	Saved              bool  `json:"saved"`
+	CommentsCount      int32 `json:"commentsCount"`
+	CommentsRestricted bool  `json:"commentsRestricted"`
+	// Title and content of deleted post are replaced with [deleted] placeholder.
+	Deleted bool `json:"deleted"`
+	// Title and content of removed post are replaced with [removed by moderator] placeholder.
+	Removed       bool       `json:"removed"`
+	RemovedBy     *uuid.UUID `json:"removedBy,omitempty"`
+	RemovedReason *string    `json:"removedReason,omitempty"`
+	RemovedAt     *time.Time `json:"removedAt,omitempty"`
+	// Time of the last edit, null if post was never edited.
+	EditedAt *time.Time `json:"editedAt,omitempty"`
+	// Starts from 1 and grows with every edit, matches number of the current version among revisions.
+	Version int32 `json:"version"`
+	// Versions of post replaced by edits, oldest first. Available to author and moderators of community.
+	Revisions []*PostRevision `json:"revisions,omitempty"`
+	// Unified diff between versions of post, where version is title, blank line and content.
+	// Versions are numbered as revisions with the current version last, diff goes to the current version when to is omitted.
+	// Available to author and moderators of community.
+	RevisionDiff *string            `json:"revisionDiff,omitempty"`
+	Comments     *CommentConnection `json:"comments"`
 }
 
 type PostConnection struct {
@@ -78,46 +228,739 @@ type PostEdge struct {
 	Node   *Post  `json:"node"`
 }
 
+// Version of post replaced by edit.
+type PostRevision struct {
+	// Revisions are numbered from 1 in order of edits.
+	Number   int32     `json:"number"`
+	Title    string    `json:"title"`
+	Content  string    `json:"content"`
+	EditorID uuid.UUID `json:"editorID"`
+	// When version was replaced.
+	EditedAt time.Time `json:"editedAt"`
+}
+
 type Query struct {
 }
 
+// Report of post, or of comment when commentID is set.
+type Report struct {
+	ID         string       `json:"id"`
+	PostID     string       `json:"postID"`
+	CommentID  *string      `json:"commentID,omitempty"`
+	Community  string       `json:"community"`
+	ReporterID uuid.UUID    `json:"reporterID"`
+	Reason     ReportReason `json:"reason"`
+	Text       *string      `json:"text,omitempty"`
+	Status     ReportStatus `json:"status"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	ResolvedBy *uuid.UUID   `json:"resolvedBy,omitempty"`
+	ResolvedAt *time.Time   `json:"resolvedAt,omitempty"`
+	// Null if post is deleted.
+	Post *Post `json:"post,omitempty"`
+	// Null for post reports.
+	Comment *Comment `json:"comment,omitempty"`
+}
+
+type ReportConnection struct {
+	Edges    []*ReportEdge `json:"edges"`
+	PageInfo *PageInfo     `json:"pageInfo"`
+}
+
+type ReportEdge struct {
+	Cursor string  `json:"cursor"`
+	Node   *Report `json:"node"`
+}
+
+type ReportInput struct {
+	// Post or comment id.
+	ID     string       `json:"id"`
+	Reason ReportReason `json:"reason"`
+	Text   *string      `json:"text,omitempty"`
+}
+
+type SavedConnection struct {
+	Edges    []*SavedEdge `json:"edges"`
+	PageInfo *PageInfo    `json:"pageInfo"`
+}
+
+type SavedEdge struct {
+	Cursor string     `json:"cursor"`
+	Node   *SavedItem `json:"node"`
+}
+
+// Post, or comment when listing saved comments, saved by the current user.
+type SavedItem struct {
+	Type    SavedType `json:"type"`
+	ItemID  string    `json:"itemID"`
+	SavedAt time.Time `json:"savedAt"`
+	// Null if post is purged.
+	Post *Post `json:"post,omitempty"`
+	// Null if comment is purged.
+	Comment *Comment `json:"comment,omitempty"`
+}
+
+type SearchConnection struct {
+	Edges    []*SearchEdge `json:"edges"`
+	PageInfo *PageInfo     `json:"pageInfo"`
+}
+
+type SearchEdge struct {
+	Cursor string        `json:"cursor"`
+	Node   *SearchResult `json:"node"`
+}
+
+// Matched post, or comment when searching comments.
+type SearchResult struct {
+	Post    *Post    `json:"post,omitempty"`
+	Comment *Comment `json:"comment,omitempty"`
+	// Relevance of match, higher is better.
+	Rank float64 `json:"rank"`
+	// HTML-escaped fragment of matched text with matched words wrapped in <mark> tags.
+	Snippet string `json:"snippet"`
+}
+
 type Subscription struct {
 }
 
 type UpdateCommentInput struct {
 	ID   string `json:"id"`
 	Text string `json:"text"`
+	// Update fails with CONFLICT error if comment version differs, e.g. when comment was edited elsewhere.
+	ExpectedVersion *int32 `json:"expectedVersion,omitempty"`
 }
 
 type UpdatePostInput struct {
 	ID      string  `json:"id"`
 	Title   *string `json:"title,omitempty"`
 	Content *string `json:"content,omitempty"`
+	// Update fails with CONFLICT error if post version differs, e.g. when post was edited elsewhere.
+	ExpectedVersion *int32 `json:"expectedVersion,omitempty"`
 }
 
 type VoteInput struct {
-	ID      string    `json:"id"`
+	ID string `json:"id"`
+	// Must be id of authenticated user.
 	VoterID uuid.UUID `json:"voterID"`
 	Value   int32     `json:"value"`
 }
 
+type ModLogAction string
+
+const (
+	ModLogActionRemovePost         ModLogAction = "REMOVE_POST"
+	ModLogActionApprovePost        ModLogAction = "APPROVE_POST"
+	ModLogActionDeletePost         ModLogAction = "DELETE_POST"
+	ModLogActionRestorePost        ModLogAction = "RESTORE_POST"
+	ModLogActionRestrictComments   ModLogAction = "RESTRICT_COMMENTS"
+	ModLogActionUnrestrictComments ModLogAction = "UNRESTRICT_COMMENTS"
+	ModLogActionRemoveComment      ModLogAction = "REMOVE_COMMENT"
+	ModLogActionApproveComment     ModLogAction = "APPROVE_COMMENT"
+	ModLogActionDeleteComment      ModLogAction = "DELETE_COMMENT"
+	ModLogActionResolveReport      ModLogAction = "RESOLVE_REPORT"
+	ModLogActionAddModerator       ModLogAction = "ADD_MODERATOR"
+	ModLogActionRemoveModerator    ModLogAction = "REMOVE_MODERATOR"
+	ModLogActionBanUser            ModLogAction = "BAN_USER"
+	ModLogActionUnbanUser          ModLogAction = "UNBAN_USER"
+)
+
+var AllModLogAction = []ModLogAction{
+	ModLogActionRemovePost,
+	ModLogActionApprovePost,
+	ModLogActionDeletePost,
+	ModLogActionRestorePost,
+	ModLogActionRestrictComments,
+	ModLogActionUnrestrictComments,
+	ModLogActionRemoveComment,
+	ModLogActionApproveComment,
+	ModLogActionDeleteComment,
+	ModLogActionResolveReport,
+	ModLogActionAddModerator,
+	ModLogActionRemoveModerator,
+	ModLogActionBanUser,
+	ModLogActionUnbanUser,
+}
+
+func (e ModLogAction) IsValid() bool {
+	switch e {
+	case ModLogActionRemovePost, ModLogActionApprovePost, ModLogActionDeletePost, ModLogActionRestorePost, ModLogActionRestrictComments, ModLogActionUnrestrictComments, ModLogActionRemoveComment, ModLogActionApproveComment, ModLogActionDeleteComment, ModLogActionResolveReport, ModLogActionAddModerator, ModLogActionRemoveModerator, ModLogActionBanUser, ModLogActionUnbanUser:
+		return true
+	}
+	return false
+}
+
+func (e ModLogAction) String() string {
+	return string(e)
+}
+
+func (e *ModLogAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ModLogAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ModLogAction", str)
+	}
+	return nil
+}
+
+func (e ModLogAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ModLogAction) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ModLogAction) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ModLogTargetType string
+
+const (
+	ModLogTargetTypePost    ModLogTargetType = "POST"
+	ModLogTargetTypeComment ModLogTargetType = "COMMENT"
+	ModLogTargetTypeUser    ModLogTargetType = "USER"
+)
+
+var AllModLogTargetType = []ModLogTargetType{
+	ModLogTargetTypePost,
+	ModLogTargetTypeComment,
+	ModLogTargetTypeUser,
+}
+
+func (e ModLogTargetType) IsValid() bool {
+	switch e {
+	case ModLogTargetTypePost, ModLogTargetTypeComment, ModLogTargetTypeUser:
+		return true
+	}
+	return false
+}
+
+func (e ModLogTargetType) String() string {
+	return string(e)
+}
+
+func (e *ModLogTargetType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ModLogTargetType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ModLogTargetType", str)
+	}
+	return nil
+}
+
+func (e ModLogTargetType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ModLogTargetType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ModLogTargetType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PostKind string
+
+const (
+	PostKindText PostKind = "TEXT"
+	// Link post points to url, its content is optional.
+	PostKindLink PostKind = "LINK"
+)
+
+var AllPostKind = []PostKind{
+	PostKindText,
+	PostKindLink,
+}
+
+func (e PostKind) IsValid() bool {
+	switch e {
+	case PostKindText, PostKindLink:
+		return true
+	}
+	return false
+}
+
+func (e PostKind) String() string {
+	return string(e)
+}
+
+func (e *PostKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostKind", str)
+	}
+	return nil
+}
+
+func (e PostKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PostKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PostKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReportAction string
+
+const (
+	// Keep content as it does not break rules.
+	ReportActionApprove ReportAction = "APPROVE"
+	// Remove reported content.
+	ReportActionRemove ReportAction = "REMOVE"
+	// Keep content as report is not actionable.
+	ReportActionDismiss ReportAction = "DISMISS"
+)
+
+var AllReportAction = []ReportAction{
+	ReportActionApprove,
+	ReportActionRemove,
+	ReportActionDismiss,
+}
+
+func (e ReportAction) IsValid() bool {
+	switch e {
+	case ReportActionApprove, ReportActionRemove, ReportActionDismiss:
+		return true
+	}
+	return false
+}
+
+func (e ReportAction) String() string {
+	return string(e)
+}
+
+func (e *ReportAction) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReportAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReportAction", str)
+	}
+	return nil
+}
+
+func (e ReportAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReportAction) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReportAction) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReportReason string
+
+const (
+	ReportReasonSpam           ReportReason = "SPAM"
+	ReportReasonHarassment     ReportReason = "HARASSMENT"
+	ReportReasonHate           ReportReason = "HATE"
+	ReportReasonViolence       ReportReason = "VIOLENCE"
+	ReportReasonMisinformation ReportReason = "MISINFORMATION"
+	ReportReasonOther          ReportReason = "OTHER"
+)
+
+var AllReportReason = []ReportReason{
+	ReportReasonSpam,
+	ReportReasonHarassment,
+	ReportReasonHate,
+	ReportReasonViolence,
+	ReportReasonMisinformation,
+	ReportReasonOther,
+}
+
+func (e ReportReason) IsValid() bool {
+	switch e {
+	case ReportReasonSpam, ReportReasonHarassment, ReportReasonHate, ReportReasonViolence, ReportReasonMisinformation, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+func (e ReportReason) String() string {
+	return string(e)
+}
+
+func (e *ReportReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReportReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReportReason", str)
+	}
+	return nil
+}
+
+func (e ReportReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReportReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReportReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "OPEN"
+	ReportStatusApproved  ReportStatus = "APPROVED"
+	ReportStatusRemoved   ReportStatus = "REMOVED"
+	ReportStatusDismissed ReportStatus = "DISMISSED"
+)
+
+var AllReportStatus = []ReportStatus{
+	ReportStatusOpen,
+	ReportStatusApproved,
+	ReportStatusRemoved,
+	ReportStatusDismissed,
+}
+
+func (e ReportStatus) IsValid() bool {
+	switch e {
+	case ReportStatusOpen, ReportStatusApproved, ReportStatusRemoved, ReportStatusDismissed:
+		return true
+	}
+	return false
+}
+
+func (e ReportStatus) String() string {
+	return string(e)
+}
+
+func (e *ReportStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReportStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReportStatus", str)
+	}
+	return nil
+}
+
+func (e ReportStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReportStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReportStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type Role string
+
+const (
+	// Manages moderators and moderates every community.
+	RoleAdmin     Role = "ADMIN"
+	RoleModerator Role = "MODERATOR"
+)
+
+var AllRole = []Role{
+	RoleAdmin,
+	RoleModerator,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleAdmin, RoleModerator:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Role) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Role) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SavedType string
+
+const (
+	SavedTypePost    SavedType = "POST"
+	SavedTypeComment SavedType = "COMMENT"
+)
+
+var AllSavedType = []SavedType{
+	SavedTypePost,
+	SavedTypeComment,
+}
+
+func (e SavedType) IsValid() bool {
+	switch e {
+	case SavedTypePost, SavedTypeComment:
+		return true
+	}
+	return false
+}
+
+func (e SavedType) String() string {
+	return string(e)
+}
+
+func (e *SavedType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SavedType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SavedType", str)
+	}
+	return nil
+}
+
+func (e SavedType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SavedType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SavedType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SearchSort string
+
+const (
+	SearchSortRelevance SearchSort = "RELEVANCE"
+	SearchSortNew       SearchSort = "NEW"
+	SearchSortRating    SearchSort = "RATING"
+)
+
+var AllSearchSort = []SearchSort{
+	SearchSortRelevance,
+	SearchSortNew,
+	SearchSortRating,
+}
+
+func (e SearchSort) IsValid() bool {
+	switch e {
+	case SearchSortRelevance, SearchSortNew, SearchSortRating:
+		return true
+	}
+	return false
+}
+
+func (e SearchSort) String() string {
+	return string(e)
+}
+
+func (e *SearchSort) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchSort", str)
+	}
+	return nil
+}
+
+func (e SearchSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SearchSort) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SearchSort) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SearchType string
+
+const (
+	SearchTypePost    SearchType = "POST"
+	SearchTypeComment SearchType = "COMMENT"
+)
+
+var AllSearchType = []SearchType{
+	SearchTypePost,
+	SearchTypeComment,
+}
+
+func (e SearchType) IsValid() bool {
+	switch e {
+	case SearchTypePost, SearchTypeComment:
+		return true
+	}
+	return false
+}
+
+func (e SearchType) String() string {
+	return string(e)
+}
+
+func (e *SearchType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchType", str)
+	}
+	return nil
+}
+
+func (e SearchType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SearchType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SearchType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type SortOrder string
 
 const (
 	SortOrderRating SortOrder = "RATING"
 	SortOrderNew    SortOrder = "NEW"
 	SortOrderOld    SortOrder = "OLD"
+	// Lower bound of Wilson score interval over upvotes and downvotes. Available for comments only.
+	SortOrderBest SortOrder = "BEST"
 )
 
 var AllSortOrder = []SortOrder{
 	SortOrderRating,
 	SortOrderNew,
 	SortOrderOld,
+	SortOrderBest,
 }
 
 func (e SortOrder) IsValid() bool {
 	switch e {
-	case SortOrderRating, SortOrderNew, SortOrderOld:
+	case SortOrderRating, SortOrderNew, SortOrderOld, SortOrderBest:
 		return true
 	}
 	return false
@@ -1,9 +1,23 @@
 package graph
 
 import (
+	"context"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/loader"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/markdown"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/ban"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/comment"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/modlog"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/post"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/preview"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/ratelimit"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/report"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/role"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/saved"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/search"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/subscription"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/visibility"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/validator"
 )
 
 // This file will not be regenerated automatically.
@@ -14,12 +28,43 @@ type Resolver struct {
 	postService         *post.Service
 	commentService      *comment.Service
 	subscriptionService *subscription.Service
+	rateLimitService    *ratelimit.Service
+	roleService         *role.Service
+	reportService       *report.Service
+	modLogService       *modlog.Service
+	banService          *ban.Service
+	searchService       *search.Service
+	savedService        *saved.Service
+	visibilityService   *visibility.Service
+	previewService      *preview.Service
+	validator           *validator.Validator
+	markdown            *markdown.Renderer
 }
 
-func NewResolver(post *post.Service, comment *comment.Service, subscription *subscription.Service) *Resolver {
+func NewResolver(post *post.Service, comment *comment.Service, subscription *subscription.Service, rateLimit *ratelimit.Service, role *role.Service, report *report.Service, modLog *modlog.Service, ban *ban.Service, search *search.Service, saved *saved.Service, visibility *visibility.Service, preview *preview.Service, validator *validator.Validator, markdown *markdown.Renderer) *Resolver {
 	return &Resolver{
 		postService:         post,
 		commentService:      comment,
 		subscriptionService: subscription,
+		rateLimitService:    rateLimit,
+		roleService:         role,
+		reportService:       report,
+		modLogService:       modLog,
+		banService:          ban,
+		searchService:       search,
+		savedService:        saved,
+		visibilityService:   visibility,
+		previewService:      preview,
+		validator:           validator,
+		markdown:            markdown,
+	}
+}
+
+// loaders returns Loaders of current request. Contexts that did not pass through loader middleware
+// get fresh loaders, so such calls are still served, just without batching across fields.
+func (r *Resolver) loaders(ctx context.Context) *loader.Loaders {
+	if loaders, ok := loader.For(ctx); ok {
+		return loaders
 	}
+	return loader.New(r.postService, r.commentService, r.savedService, r.previewService)
 }
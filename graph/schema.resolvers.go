@@ -6,24 +6,163 @@ package graph
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log/slog"
 	"strconv"
 
+	"github.com/google/uuid"
 	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/converter"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
-	"github.com/trust-me-im-an-engineer/mini-reddit/internal/validator"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/markdown"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/ratelimit"
 )
 
+// TextHTML is the resolver for the textHTML field.
+func (r *commentResolver) TextHTML(ctx context.Context, obj *model.Comment) (string, error) {
+	// Placeholders of deleted and removed comments are not revisions
+	if obj.Deleted || obj.Removed {
+		html, err := r.markdown.RenderUncached(obj.Text)
+		if err != nil {
+			slog.Error("failed to render comment placeholder", "id", obj.ID, "error", err)
+			return "", errs.InternalServer
+		}
+		return html, nil
+	}
+
+	html, err := r.markdown.Render(markdown.KindComment, obj.ID, obj.Version, obj.Text)
+	if err != nil {
+		slog.Error("failed to render comment text", "id", obj.ID, "error", err)
+		return "", errs.InternalServer
+	}
+	return html, nil
+}
+
+// MyVote is the resolver for the myVote field.
+func (r *commentResolver) MyVote(ctx context.Context, obj *model.Comment) (*int32, error) {
+	id, _ := strconv.Atoi(obj.ID) // id comes from already converted domain comment
+
+	vote, err := r.loaders(ctx).CommentVote.Load(ctx, id)()
+	if err != nil {
+		slog.Error("failed to load comment vote", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.MyVote_DomainToModel(vote), nil
+}
+
+// Saved is the resolver for the saved field.
+func (r *commentResolver) Saved(ctx context.Context, obj *model.Comment) (bool, error) {
+	id, _ := strconv.Atoi(obj.ID) // id comes from already converted domain comment
+
+	saved, err := r.loaders(ctx).CommentSaved.Load(ctx, id)()
+	if err != nil {
+		slog.Error("failed to load comment saved flag", "id", id, "error", err)
+		return false, errs.InternalServer
+	}
+
+	return saved, nil
+}
+
+// Revisions is the resolver for the revisions field.
+func (r *commentResolver) Revisions(ctx context.Context, obj *model.Comment) ([]*model.CommentRevision, error) {
+	id, _ := strconv.Atoi(obj.ID) // id comes from already converted domain comment
+
+	domainRevisions, err := r.commentService.GetRevisions(ctx, id)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("comment service failed to get revisions", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.CommentRevisions_DomainToModel(domainRevisions), nil
+}
+
+// RevisionDiff is the resolver for the revisionDiff field.
+func (r *commentResolver) RevisionDiff(ctx context.Context, obj *model.Comment, from int32, to *int32) (*string, error) {
+	id, _ := strconv.Atoi(obj.ID) // id comes from already converted domain comment
+	domainFrom, domainTo := converter.RevisionRange(from, to)
+
+	diff, err := r.commentService.GetRevisionDiff(ctx, id, domainFrom, domainTo)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("comment service failed to get revision diff", "id", id, "from", from, "to", to, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return &diff, nil
+}
+
 // Children is the resolver for the children field.
 func (r *commentResolver) Children(ctx context.Context, obj *model.Comment, sort model.SortOrder, limit int32, cursor *string, depth int32) (*model.CommentConnection, error) {
-	panic(fmt.Errorf("not implemented: Children - children"))
+	if err := r.validator.ValidateCommentsInput(limit, depth); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+	if commentsDepthLeft(ctx, depth) <= 0 {
+		return emptyCommentConnection(), nil
+	}
+
+	// ids come from already converted domain comment
+	postID, _ := strconv.Atoi(obj.PostID)
+	parentID, _ := strconv.Atoi(obj.ID)
+	domainInput := converter.CommentsInput(postID, &parentID, sort, limit, cursor)
+
+	domainConnection, err := r.getComments(ctx, domainInput)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("comment service failed to get children", "parentID", parentID, "sort", sort, "cursor", cursor, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.CommentConnection_DomainToModel(domainConnection), nil
+}
+
+// ParentTree is the resolver for the parentTree field.
+func (r *commentResolver) ParentTree(ctx context.Context, obj *model.Comment, depth *int32) ([]*model.Comment, error) {
+	maxDepth := int32(1)
+	if depth != nil {
+		maxDepth = *depth
+	}
+	if err := r.validator.ValidateParentTreeInput(maxDepth); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	// Ancestors are loaded level by level, so sibling nodes share every batch
+	tree := make([]*model.Comment, 0, maxDepth)
+	parentID := obj.ParentID
+	for int32(len(tree)) < maxDepth && parentID != nil {
+		id, _ := strconv.Atoi(*parentID) // id comes from already converted domain comment
+
+		parent, err := r.loaders(ctx).Comment.Load(ctx, id)()
+		if err := errs.Exposable(err); err != nil {
+			return nil, err
+		}
+		if err != nil {
+			slog.Error("failed to load parent comment", "id", id, "error", err)
+			return nil, errs.InternalServer
+		}
+
+		modelParent := converter.Comment_DomainToModel(parent)
+		tree = append(tree, modelParent)
+		parentID = modelParent.ParentID
+	}
+
+	return tree, nil
 }
 
 // CreatePost is the resolver for the createPost field.
 func (r *mutationResolver) CreatePost(ctx context.Context, input model.CreatePostInput) (*model.Post, error) {
-	if err := validator.ValidateCreatePostInput(input); err != nil {
+	if err := r.rateLimitService.Allow(ctx, ratelimit.ActionCreatePost); err != nil {
+		return nil, rateLimitError(err)
+	}
+
+	if err := r.validator.ValidateCreatePostInput(&input); err != nil {
 		return nil, errs.InvalidInputWrap(err)
 	}
 
@@ -38,12 +177,30 @@ func (r *mutationResolver) CreatePost(ctx context.Context, input model.CreatePos
 		return nil, errs.InternalServer
 	}
 
+	if input.Kind == model.PostKindLink {
+		r.previewService.Notify()
+	}
+
 	return converter.Post_DomainToModel(domainPost), nil
 }
 
 // UpdatePost is the resolver for the updatePost field.
 func (r *mutationResolver) UpdatePost(ctx context.Context, input model.UpdatePostInput) (*model.Post, error) {
-	if err := validator.ValidateUpdatePostInput(input); err != nil {
+	// Content is optional for link posts only, so input is validated against kind of edited post
+	kind := model.PostKindText
+	if id, err := strconv.Atoi(input.ID); err == nil {
+		post, err := r.loaders(ctx).Post.Load(ctx, id)()
+		if err := errs.Exposable(err); err != nil {
+			return nil, err
+		}
+		if err != nil {
+			slog.Error("failed to load post to update", "id", id, "error", err)
+			return nil, errs.InternalServer
+		}
+		kind = converter.Post_DomainToModel(post).Kind
+	}
+
+	if err := r.validator.ValidateUpdatePostInput(&input, kind); err != nil {
 		return nil, errs.InvalidInputWrap(err)
 	}
 
@@ -65,7 +222,7 @@ func (r *mutationResolver) UpdatePost(ctx context.Context, input model.UpdatePos
 func (r *mutationResolver) DeletePost(ctx context.Context, id string) (bool, error) {
 	domainID, err := strconv.Atoi(id)
 	if err != nil {
-		return false, errs.InvalidInputWrap(errs.InvalidID)
+		return false, errs.InvalidInputWrap(errs.InvalidIDField("id"))
 	}
 
 	err = r.postService.DeletePost(ctx, domainID)
@@ -80,11 +237,30 @@ func (r *mutationResolver) DeletePost(ctx context.Context, id string) (bool, err
 	return true, nil
 }
 
+// RestorePost is the resolver for the restorePost field.
+func (r *mutationResolver) RestorePost(ctx context.Context, id string) (*model.Post, error) {
+	domainID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.InvalidInputWrap(errs.InvalidIDField("id"))
+	}
+
+	domainPost, err := r.postService.RestorePost(ctx, domainID)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("post service failed to restore post", "id", domainID, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Post_DomainToModel(domainPost), nil
+}
+
 // SetCommentsRestricted is the resolver for the setCommentsRestricted field.
 func (r *mutationResolver) SetCommentsRestricted(ctx context.Context, postID string, restricted bool) (*model.Post, error) {
 	domainID, err := strconv.Atoi(postID)
 	if err != nil {
-		return nil, errs.InvalidInputWrap(fmt.Errorf("invalid post id: %w", err))
+		return nil, errs.InvalidInputWrap(errs.InvalidIDField("postID"))
 	}
 
 	domainPost, err := r.postService.SetCommentsRestricted(ctx, domainID, restricted)
@@ -101,7 +277,11 @@ func (r *mutationResolver) SetCommentsRestricted(ctx context.Context, postID str
 
 // VotePost is the resolver for the votePost field.
 func (r *mutationResolver) VotePost(ctx context.Context, input model.VoteInput) (*model.Post, error) {
-	if err := validator.ValidateVoteInput(input); err != nil {
+	if err := r.rateLimitService.Allow(ctx, ratelimit.ActionVote); err != nil {
+		return nil, rateLimitError(err)
+	}
+
+	if err := r.validator.ValidateVoteInput(input); err != nil {
 		return nil, errs.InvalidInputWrap(err)
 	}
 
@@ -121,13 +301,17 @@ func (r *mutationResolver) VotePost(ctx context.Context, input model.VoteInput)
 
 // CreateComment is the resolver for the createComment field.
 func (r *mutationResolver) CreateComment(ctx context.Context, input model.CreateCommentInput) (*model.Comment, error) {
-	if err := validator.ValidateCreateCommentInput(input); err != nil {
+	if err := r.rateLimitService.Allow(ctx, ratelimit.ActionCreateComment); err != nil {
+		return nil, rateLimitError(err)
+	}
+
+	if err := r.validator.ValidateCreateCommentInput(&input); err != nil {
 		return nil, errs.InvalidInputWrap(err)
 	}
 
 	domainInput := converter.CreateCommentInput_ModelToDomain(&input)
 
-	domainComment, err := r.commentService.CreateComment(ctx, domainInput)
+	domainComment, replayed, err := r.commentService.CreateComment(ctx, domainInput)
 	if err := errs.Exposable(err); err != nil {
 		return nil, err
 	}
@@ -138,14 +322,17 @@ func (r *mutationResolver) CreateComment(ctx context.Context, input model.Create
 
 	modelComment := converter.Comment_DomainToModel(domainComment)
 
-	r.subscriptionService.PublishComment(domainInput.PostID, modelComment)
+	// Subscribers already got comment when it was created
+	if !replayed {
+		r.subscriptionService.PublishComment(domainInput.PostID, modelComment)
+	}
 
 	return modelComment, nil
 }
 
 // UpdateComment is the resolver for the updateComment field.
 func (r *mutationResolver) UpdateComment(ctx context.Context, input model.UpdateCommentInput) (*model.Comment, error) {
-	if err := validator.ValidateUpdateCommentInput(input); err != nil {
+	if err := r.validator.ValidateUpdateCommentInput(&input); err != nil {
 		return nil, errs.InvalidInputWrap(err)
 	}
 
@@ -167,7 +354,7 @@ func (r *mutationResolver) UpdateComment(ctx context.Context, input model.Update
 func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (bool, error) {
 	domainID, err := strconv.Atoi(id)
 	if err != nil {
-		return false, errs.InvalidInputWrap(errs.InvalidID)
+		return false, errs.InvalidInputWrap(errs.InvalidIDField("id"))
 	}
 
 	err = r.commentService.DeleteComment(ctx, domainID)
@@ -184,7 +371,11 @@ func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (bool,
 
 // VoteComment is the resolver for the voteComment field.
 func (r *mutationResolver) VoteComment(ctx context.Context, input model.VoteInput) (*model.Comment, error) {
-	if err := validator.ValidateVoteInput(input); err != nil {
+	if err := r.rateLimitService.Allow(ctx, ratelimit.ActionVote); err != nil {
+		return nil, rateLimitError(err)
+	}
+
+	if err := r.validator.ValidateVoteInput(input); err != nil {
 		return nil, errs.InvalidInputWrap(err)
 	}
 
@@ -202,99 +393,822 @@ func (r *mutationResolver) VoteComment(ctx context.Context, input model.VoteInpu
 	return converter.Comment_DomainToModel(domainComment), nil
 }
 
-// Comments is the resolver for the comments field.
-func (r *postResolver) Comments(ctx context.Context, obj *model.Post, sort model.SortOrder, limit int32, cursor *string, depth int32) (*model.CommentConnection, error) {
-	panic(fmt.Errorf("not implemented: Comments - comments"))
+// AddModerator is the resolver for the addModerator field.
+func (r *mutationResolver) AddModerator(ctx context.Context, community string, userID uuid.UUID) (*model.Moderator, error) {
+	if err := r.validator.ValidateCommunityInput(&community); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainModerator, err := r.roleService.AddModerator(ctx, community, userID)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("role service failed to add moderator", "community", community, "userID", userID, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Moderator_DomainToModel(domainModerator), nil
 }
 
-// Post is the resolver for the post field.
-func (r *queryResolver) Post(ctx context.Context, id string) (*model.Post, error) {
+// RemoveModerator is the resolver for the removeModerator field.
+func (r *mutationResolver) RemoveModerator(ctx context.Context, community string, userID uuid.UUID) (bool, error) {
+	if err := r.validator.ValidateCommunityInput(&community); err != nil {
+		return false, errs.InvalidInputWrap(err)
+	}
+
+	err := r.roleService.RemoveModerator(ctx, community, userID)
+	if err := errs.Exposable(err); err != nil {
+		return false, err
+	}
+	if err != nil {
+		slog.Error("role service failed to remove moderator", "community", community, "userID", userID, "error", err)
+		return false, errs.InternalServer
+	}
+
+	return true, nil
+}
+
+// RemovePost is the resolver for the removePost field.
+func (r *mutationResolver) RemovePost(ctx context.Context, id string, reason *string) (*model.Post, error) {
+	if err := r.validator.ValidateRemoveInput(id, &reason); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainID, _ := strconv.Atoi(id) // id already validated
+	domainPost, err := r.postService.RemovePost(ctx, domainID, reason)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("post service failed to remove post", "id", domainID, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Post_DomainToModel(domainPost), nil
+}
+
+// ApprovePost is the resolver for the approvePost field.
+func (r *mutationResolver) ApprovePost(ctx context.Context, id string) (*model.Post, error) {
 	domainID, err := strconv.Atoi(id)
 	if err != nil {
-		return nil, errs.InvalidInputWrap(errs.InvalidID)
+		return nil, errs.InvalidInputWrap(errs.InvalidIDField("id"))
 	}
 
-	internalPost, err := r.postService.GetPost(ctx, domainID)
+	domainPost, err := r.postService.ApprovePost(ctx, domainID)
 	if err := errs.Exposable(err); err != nil {
 		return nil, err
 	}
 	if err != nil {
-		slog.Error("post service failed to get post", "id", domainID, "error", err)
+		slog.Error("post service failed to approve post", "id", domainID, "error", err)
 		return nil, errs.InternalServer
 	}
 
-	return converter.Post_DomainToModel(internalPost), nil
+	return converter.Post_DomainToModel(domainPost), nil
 }
 
-// Posts is the resolver for the posts field.
-func (r *queryResolver) Posts(ctx context.Context, sort model.SortOrder, limit int32, cursor *string) (*model.PostConnection, error) {
-	if err := validator.ValidatePostsInput(limit); err != nil {
+// RemoveComment is the resolver for the removeComment field.
+func (r *mutationResolver) RemoveComment(ctx context.Context, id string, reason *string) (*model.Comment, error) {
+	if err := r.validator.ValidateRemoveInput(id, &reason); err != nil {
 		return nil, errs.InvalidInputWrap(err)
 	}
 
-	domainInput := converter.PostsInput(sort, limit, cursor)
+	domainID, _ := strconv.Atoi(id) // id already validated
+	domainComment, err := r.commentService.RemoveComment(ctx, domainID, reason)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("comment service failed to remove comment", "id", domainID, "error", err)
+		return nil, errs.InternalServer
+	}
 
-	domainPostConnection, err := r.postService.GetPosts(ctx, domainInput)
+	return converter.Comment_DomainToModel(domainComment), nil
+}
+
+// ApproveComment is the resolver for the approveComment field.
+func (r *mutationResolver) ApproveComment(ctx context.Context, id string) (*model.Comment, error) {
+	domainID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.InvalidInputWrap(errs.InvalidIDField("id"))
+	}
+
+	domainComment, err := r.commentService.ApproveComment(ctx, domainID)
 	if err := errs.Exposable(err); err != nil {
 		return nil, err
 	}
 	if err != nil {
-		slog.Error("post service failed to get posts", "sort", domainInput.Sort, "limit", domainInput.Limit, "cursor", cursor, "error", err)
+		slog.Error("comment service failed to approve comment", "id", domainID, "error", err)
 		return nil, errs.InternalServer
 	}
 
-	return converter.PostConnection_DomainToModel(domainPostConnection), nil
+	return converter.Comment_DomainToModel(domainComment), nil
 }
 
-// Comment is the resolver for the comment field.
-func (r *queryResolver) Comment(ctx context.Context, id string) (*model.Comment, error) {
+// ReportPost is the resolver for the reportPost field.
+func (r *mutationResolver) ReportPost(ctx context.Context, input model.ReportInput) (*model.Report, error) {
+	if err := r.validator.ValidateReportInput(&input); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	id, _ := strconv.Atoi(input.ID) // id already validated
+	domainReport, err := r.reportService.ReportPost(ctx, id, converter.ReportReason_ModelToDomain(input.Reason), input.Text)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("report service failed to report post", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Report_DomainToModel(domainReport), nil
+}
+
+// ReportComment is the resolver for the reportComment field.
+func (r *mutationResolver) ReportComment(ctx context.Context, input model.ReportInput) (*model.Report, error) {
+	if err := r.validator.ValidateReportInput(&input); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	id, _ := strconv.Atoi(input.ID) // id already validated
+	domainReport, err := r.reportService.ReportComment(ctx, id, converter.ReportReason_ModelToDomain(input.Reason), input.Text)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("report service failed to report comment", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Report_DomainToModel(domainReport), nil
+}
+
+// ResolveReport is the resolver for the resolveReport field.
+func (r *mutationResolver) ResolveReport(ctx context.Context, id string, action model.ReportAction) (*model.Report, error) {
 	domainID, err := strconv.Atoi(id)
 	if err != nil {
-		return nil, errs.InvalidInputWrap(errs.InvalidID)
+		return nil, errs.InvalidInputWrap(errs.InvalidIDField("id"))
 	}
 
-	internalComment, err := r.commentService.GetComment(ctx, domainID)
+	domainReport, err := r.reportService.ResolveReport(ctx, domainID, converter.ReportAction_ModelToDomain(action))
 	if err := errs.Exposable(err); err != nil {
 		return nil, err
 	}
 	if err != nil {
-		slog.Error("comment service failed to get comment", "id", domainID, "error", err)
+		slog.Error("report service failed to resolve report", "id", domainID, "action", action, "error", err)
 		return nil, errs.InternalServer
 	}
 
-	return converter.Comment_DomainToModel(internalComment), nil
+	return converter.Report_DomainToModel(domainReport), nil
 }
 
-// NewComment is the resolver for the newComment field.
-func (r *subscriptionResolver) NewComment(ctx context.Context, postID string) (<-chan *model.Comment, error) {
-	domainPostID, err := strconv.Atoi(postID)
+// BanUser is the resolver for the banUser field.
+func (r *mutationResolver) BanUser(ctx context.Context, input model.BanInput) (*model.Ban, error) {
+	if err := r.validator.ValidateBanInput(&input); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainInput := converter.BanInput_ModelToDomain(&input)
+
+	domainBan, err := r.banService.BanUser(ctx, domainInput)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
 	if err != nil {
-		return nil, errs.InvalidInputWrap(errs.InvalidID)
+		slog.Error("ban service failed to ban user", "userID", input.UserID, "community", input.Community, "error", err)
+		return nil, errs.InternalServer
 	}
 
-	ch := make(chan *model.Comment, 10)
+	return converter.Ban_DomainToModel(domainBan), nil
+}
 
-	r.subscriptionService.SubscribeToPost(domainPostID, ch)
-	go func() {
-		<-ctx.Done()
-		r.subscriptionService.UnsubscribeFromPost(domainPostID, ch)
-		slog.Debug("subscription closed", "postID", domainPostID)
-	}()
+// UnbanUser is the resolver for the unbanUser field.
+func (r *mutationResolver) UnbanUser(ctx context.Context, userID uuid.UUID, community *string) (bool, error) {
+	if err := r.validator.ValidateOptionalCommunityInput(community); err != nil {
+		return false, errs.InvalidInputWrap(err)
+	}
 
-	return ch, nil
+	err := r.banService.UnbanUser(ctx, community, userID)
+	if err := errs.Exposable(err); err != nil {
+		return false, err
+	}
+	if err != nil {
+		slog.Error("ban service failed to unban user", "userID", userID, "community", community, "error", err)
+		return false, errs.InternalServer
+	}
+
+	return true, nil
 }
 
-// Comment returns CommentResolver implementation.
-func (r *Resolver) Comment() CommentResolver { return &commentResolver{r} }
+// SavePost is the resolver for the savePost field.
+func (r *mutationResolver) SavePost(ctx context.Context, id string) (*model.Post, error) {
+	domainID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.InvalidInputWrap(errs.InvalidIDField("id"))
+	}
 
-// Mutation returns MutationResolver implementation.
-func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+	domainPost, err := r.savedService.SavePost(ctx, domainID)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("saved service failed to save post", "id", domainID, "error", err)
+		return nil, errs.InternalServer
+	}
 
-// Post returns PostResolver implementation.
-func (r *Resolver) Post() PostResolver { return &postResolver{r} }
+	return converter.Post_DomainToModel(domainPost), nil
+}
 
-// Query returns QueryResolver implementation.
-func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+// UnsavePost is the resolver for the unsavePost field.
+func (r *mutationResolver) UnsavePost(ctx context.Context, id string) (bool, error) {
+	domainID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, errs.InvalidInputWrap(errs.InvalidIDField("id"))
+	}
+
+	err = r.savedService.UnsavePost(ctx, domainID)
+	if err := errs.Exposable(err); err != nil {
+		return false, err
+	}
+	if err != nil {
+		slog.Error("saved service failed to unsave post", "id", domainID, "error", err)
+		return false, errs.InternalServer
+	}
+
+	return true, nil
+}
+
+// SaveComment is the resolver for the saveComment field.
+func (r *mutationResolver) SaveComment(ctx context.Context, id string) (*model.Comment, error) {
+	domainID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.InvalidInputWrap(errs.InvalidIDField("id"))
+	}
+
+	domainComment, err := r.savedService.SaveComment(ctx, domainID)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("saved service failed to save comment", "id", domainID, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Comment_DomainToModel(domainComment), nil
+}
+
+// UnsaveComment is the resolver for the unsaveComment field.
+func (r *mutationResolver) UnsaveComment(ctx context.Context, id string) (bool, error) {
+	domainID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, errs.InvalidInputWrap(errs.InvalidIDField("id"))
+	}
+
+	err = r.savedService.UnsaveComment(ctx, domainID)
+	if err := errs.Exposable(err); err != nil {
+		return false, err
+	}
+	if err != nil {
+		slog.Error("saved service failed to unsave comment", "id", domainID, "error", err)
+		return false, errs.InternalServer
+	}
+
+	return true, nil
+}
+
+// HidePost is the resolver for the hidePost field.
+func (r *mutationResolver) HidePost(ctx context.Context, id string) (bool, error) {
+	domainID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, errs.InvalidInputWrap(errs.InvalidIDField("id"))
+	}
+
+	err = r.visibilityService.HidePost(ctx, domainID)
+	if err := errs.Exposable(err); err != nil {
+		return false, err
+	}
+	if err != nil {
+		slog.Error("visibility service failed to hide post", "id", domainID, "error", err)
+		return false, errs.InternalServer
+	}
+
+	return true, nil
+}
+
+// UnhidePost is the resolver for the unhidePost field.
+func (r *mutationResolver) UnhidePost(ctx context.Context, id string) (bool, error) {
+	domainID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, errs.InvalidInputWrap(errs.InvalidIDField("id"))
+	}
+
+	err = r.visibilityService.UnhidePost(ctx, domainID)
+	if err := errs.Exposable(err); err != nil {
+		return false, err
+	}
+	if err != nil {
+		slog.Error("visibility service failed to unhide post", "id", domainID, "error", err)
+		return false, errs.InternalServer
+	}
+
+	return true, nil
+}
+
+// BlockUser is the resolver for the blockUser field.
+func (r *mutationResolver) BlockUser(ctx context.Context, userID uuid.UUID) (*model.BlockedUser, error) {
+	domainBlock, err := r.visibilityService.BlockUser(ctx, userID)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("visibility service failed to block user", "userID", userID, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.BlockedUser_DomainToModel(domainBlock), nil
+}
+
+// UnblockUser is the resolver for the unblockUser field.
+func (r *mutationResolver) UnblockUser(ctx context.Context, userID uuid.UUID) (bool, error) {
+	err := r.visibilityService.UnblockUser(ctx, userID)
+	if err := errs.Exposable(err); err != nil {
+		return false, err
+	}
+	if err != nil {
+		slog.Error("visibility service failed to unblock user", "userID", userID, "error", err)
+		return false, errs.InternalServer
+	}
+
+	return true, nil
+}
+
+// ContentHTML is the resolver for the contentHTML field.
+func (r *postResolver) ContentHTML(ctx context.Context, obj *model.Post) (string, error) {
+	// Placeholders of deleted and removed posts are not revisions
+	if obj.Deleted || obj.Removed {
+		html, err := r.markdown.RenderUncached(obj.Content)
+		if err != nil {
+			slog.Error("failed to render post placeholder", "id", obj.ID, "error", err)
+			return "", errs.InternalServer
+		}
+		return html, nil
+	}
+
+	html, err := r.markdown.Render(markdown.KindPost, obj.ID, obj.Version, obj.Content)
+	if err != nil {
+		slog.Error("failed to render post content", "id", obj.ID, "error", err)
+		return "", errs.InternalServer
+	}
+	return html, nil
+}
+
+// DuplicateLinks is the resolver for the duplicateLinks field.
+func (r *postResolver) DuplicateLinks(ctx context.Context, obj *model.Post) ([]*model.Post, error) {
+	if obj.Kind != model.PostKindLink {
+		return []*model.Post{}, nil
+	}
+	id, _ := strconv.Atoi(obj.ID) // id comes from already converted domain post
+
+	domainPosts, err := r.postService.GetDuplicateLinks(ctx, id)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("post service failed to get duplicate links", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Posts_DomainToModel(domainPosts), nil
+}
+
+// Preview is the resolver for the preview field.
+func (r *postResolver) Preview(ctx context.Context, obj *model.Post) (*model.LinkPreview, error) {
+	if obj.Kind != model.PostKindLink || obj.Deleted || obj.Removed {
+		return nil, nil
+	}
+	id, _ := strconv.Atoi(obj.ID) // id comes from already converted domain post
+
+	preview, err := r.loaders(ctx).PostPreview.Load(ctx, id)()
+	if err != nil {
+		slog.Error("failed to load link preview", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.LinkPreview_DomainToModel(preview), nil
+}
+
+// MyVote is the resolver for the myVote field.
+func (r *postResolver) MyVote(ctx context.Context, obj *model.Post) (*int32, error) {
+	id, _ := strconv.Atoi(obj.ID) // id comes from already converted domain post
+
+	vote, err := r.loaders(ctx).PostVote.Load(ctx, id)()
+	if err != nil {
+		slog.Error("failed to load post vote", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.MyVote_DomainToModel(vote), nil
+}
+
+// Saved is the resolver for the saved field.
+func (r *postResolver) Saved(ctx context.Context, obj *model.Post) (bool, error) {
+	id, _ := strconv.Atoi(obj.ID) // id comes from already converted domain post
+
+	saved, err := r.loaders(ctx).PostSaved.Load(ctx, id)()
+	if err != nil {
+		slog.Error("failed to load post saved flag", "id", id, "error", err)
+		return false, errs.InternalServer
+	}
+
+	return saved, nil
+}
+
+// Revisions is the resolver for the revisions field.
+func (r *postResolver) Revisions(ctx context.Context, obj *model.Post) ([]*model.PostRevision, error) {
+	id, _ := strconv.Atoi(obj.ID) // id comes from already converted domain post
+
+	domainRevisions, err := r.postService.GetRevisions(ctx, id)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("post service failed to get revisions", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.PostRevisions_DomainToModel(domainRevisions), nil
+}
+
+// RevisionDiff is the resolver for the revisionDiff field.
+func (r *postResolver) RevisionDiff(ctx context.Context, obj *model.Post, from int32, to *int32) (*string, error) {
+	id, _ := strconv.Atoi(obj.ID) // id comes from already converted domain post
+	domainFrom, domainTo := converter.RevisionRange(from, to)
+
+	diff, err := r.postService.GetRevisionDiff(ctx, id, domainFrom, domainTo)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("post service failed to get revision diff", "id", id, "from", from, "to", to, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return &diff, nil
+}
+
+// Comments is the resolver for the comments field.
+func (r *postResolver) Comments(ctx context.Context, obj *model.Post, sort model.SortOrder, limit int32, cursor *string, depth int32) (*model.CommentConnection, error) {
+	if err := r.validator.ValidateCommentsInput(limit, depth); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+	if commentsDepthLeft(ctx, depth) <= 0 {
+		return emptyCommentConnection(), nil
+	}
+
+	postID, _ := strconv.Atoi(obj.ID) // id comes from already converted domain post
+	domainInput := converter.CommentsInput(postID, nil, sort, limit, cursor)
+
+	domainConnection, err := r.getComments(ctx, domainInput)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("comment service failed to get comments", "postID", postID, "sort", sort, "cursor", cursor, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.CommentConnection_DomainToModel(domainConnection), nil
+}
+
+// Post is the resolver for the post field.
+func (r *queryResolver) Post(ctx context.Context, id string) (*model.Post, error) {
+	domainID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.InvalidInputWrap(errs.InvalidIDField("id"))
+	}
+
+	internalPost, err := r.loaders(ctx).Post.Load(ctx, domainID)()
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("post service failed to get post", "id", domainID, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Post_DomainToModel(internalPost), nil
+}
+
+// Posts is the resolver for the posts field.
+func (r *queryResolver) Posts(ctx context.Context, sort model.SortOrder, limit int32, cursor *string) (*model.PostConnection, error) {
+	if err := r.validator.ValidatePostsInput(sort, limit); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainInput := converter.PostsInput(sort, limit, cursor)
+
+	domainPostConnection, err := r.postService.GetPosts(ctx, domainInput)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("post service failed to get posts", "sort", domainInput.Sort, "limit", domainInput.Limit, "cursor", cursor, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.PostConnection_DomainToModel(domainPostConnection), nil
+}
+
+// PostsByDomain is the resolver for the postsByDomain field.
+func (r *queryResolver) PostsByDomain(ctx context.Context, domain string, sort model.SortOrder, limit int32, cursor *string) (*model.PostConnection, error) {
+	if err := r.validator.ValidatePostsByDomainInput(&domain, sort, limit); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainInput := converter.PostsByDomainInput(domain, sort, limit, cursor)
+
+	domainPostConnection, err := r.postService.GetPosts(ctx, domainInput)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("post service failed to get posts by domain", "domain", domain, "sort", domainInput.Sort, "limit", domainInput.Limit, "cursor", cursor, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.PostConnection_DomainToModel(domainPostConnection), nil
+}
+
+// Comment is the resolver for the comment field.
+func (r *queryResolver) Comment(ctx context.Context, id string) (*model.Comment, error) {
+	domainID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.InvalidInputWrap(errs.InvalidIDField("id"))
+	}
+
+	internalComment, err := r.loaders(ctx).Comment.Load(ctx, domainID)()
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("comment service failed to get comment", "id", domainID, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Comment_DomainToModel(internalComment), nil
+}
+
+// Search is the resolver for the search field.
+func (r *queryResolver) Search(ctx context.Context, query string, typeArg model.SearchType, sort model.SearchSort, limit int32, cursor *string) (*model.SearchConnection, error) {
+	if err := r.validator.ValidateSearchInput(&query, typeArg, limit); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainInput := converter.SearchInput(query, typeArg, sort, limit, cursor)
+
+	domainConnection, err := r.searchService.Search(ctx, domainInput)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("search service failed to search", "query", query, "type", typeArg, "sort", sort, "cursor", cursor, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.SearchConnection_DomainToModel(domainConnection), nil
+}
+
+// Moderators is the resolver for the moderators field.
+func (r *queryResolver) Moderators(ctx context.Context, community string) ([]*model.Moderator, error) {
+	if err := r.validator.ValidateCommunityInput(&community); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainModerators, err := r.roleService.GetModerators(ctx, community)
+	if err != nil {
+		slog.Error("role service failed to get moderators", "community", community, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Moderators_DomainToModel(domainModerators), nil
+}
+
+// MyRole is the resolver for the myRole field.
+func (r *queryResolver) MyRole(ctx context.Context, community string) (*model.Role, error) {
+	if err := r.validator.ValidateCommunityInput(&community); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainRole, err := r.roleService.RoleIn(ctx, community)
+	if err != nil {
+		slog.Error("role service failed to get role", "community", community, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Role_DomainToModel(domainRole), nil
+}
+
+// ModerationQueue is the resolver for the moderationQueue field.
+func (r *queryResolver) ModerationQueue(ctx context.Context, community string, status model.ReportStatus, limit int32, cursor *string) (*model.ReportConnection, error) {
+	if err := r.validator.ValidateModerationQueueInput(&community, limit); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainInput := converter.ModerationQueueInput(community, status, limit, cursor)
+
+	domainConnection, err := r.reportService.GetModerationQueue(ctx, domainInput)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("report service failed to get moderation queue", "community", community, "status", status, "cursor", cursor, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.ReportConnection_DomainToModel(domainConnection), nil
+}
+
+// ModLog is the resolver for the modLog field.
+func (r *queryResolver) ModLog(ctx context.Context, community *string, actorID *uuid.UUID, action *model.ModLogAction, limit int32, cursor *string) (*model.ModLogConnection, error) {
+	if err := r.validator.ValidateModLogInput(community, limit); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainInput := converter.ModLogInput(community, actorID, action, limit, cursor)
+
+	domainConnection, err := r.modLogService.GetModLog(ctx, domainInput)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("mod log service failed to get mod log", "community", community, "actorID", actorID, "action", action, "cursor", cursor, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.ModLogConnection_DomainToModel(domainConnection), nil
+}
+
+// Bans is the resolver for the bans field.
+func (r *queryResolver) Bans(ctx context.Context, community *string) ([]*model.Ban, error) {
+	if err := r.validator.ValidateOptionalCommunityInput(community); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainBans, err := r.banService.GetActiveBans(ctx, community)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("ban service failed to get active bans", "community", community, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Bans_DomainToModel(domainBans), nil
+}
+
+// Saved is the resolver for the saved field.
+func (r *queryResolver) Saved(ctx context.Context, typeArg model.SavedType, limit int32, cursor *string) (*model.SavedConnection, error) {
+	if err := r.validator.ValidateSavedInput(typeArg, limit); err != nil {
+		return nil, errs.InvalidInputWrap(err)
+	}
+
+	domainInput := converter.SavedInput(typeArg, limit, cursor)
+
+	domainConnection, err := r.savedService.GetSaved(ctx, domainInput)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("saved service failed to get saved items", "type", typeArg, "cursor", cursor, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.SavedConnection_DomainToModel(domainConnection), nil
+}
+
+// BlockedUsers is the resolver for the blockedUsers field.
+func (r *queryResolver) BlockedUsers(ctx context.Context) ([]*model.BlockedUser, error) {
+	domainBlocks, err := r.visibilityService.GetBlockedUsers(ctx)
+	if err := errs.Exposable(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		slog.Error("visibility service failed to get blocked users", "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.BlockedUsers_DomainToModel(domainBlocks), nil
+}
+
+// Post is the resolver for the post field.
+func (r *reportResolver) Post(ctx context.Context, obj *model.Report) (*model.Post, error) {
+	id, _ := strconv.Atoi(obj.PostID) // id comes from already converted domain report
+
+	post, err := r.loaders(ctx).Post.Load(ctx, id)()
+	if errors.Is(err, errs.PostNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		slog.Error("failed to load reported post", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Post_DomainToModel(post), nil
+}
+
+// Comment is the resolver for the comment field.
+func (r *reportResolver) Comment(ctx context.Context, obj *model.Report) (*model.Comment, error) {
+	if obj.CommentID == nil {
+		return nil, nil
+	}
+	id, _ := strconv.Atoi(*obj.CommentID) // id comes from already converted domain report
+
+	comment, err := r.loaders(ctx).Comment.Load(ctx, id)()
+	if errors.Is(err, errs.CommentNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		slog.Error("failed to load reported comment", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Comment_DomainToModel(comment), nil
+}
+
+// Post is the resolver for the post field.
+func (r *savedItemResolver) Post(ctx context.Context, obj *model.SavedItem) (*model.Post, error) {
+	if obj.Type != model.SavedTypePost {
+		return nil, nil
+	}
+	id, _ := strconv.Atoi(obj.ItemID) // id comes from already converted domain saved item
+
+	post, err := r.loaders(ctx).Post.Load(ctx, id)()
+	if errors.Is(err, errs.PostNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		slog.Error("failed to load saved post", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Post_DomainToModel(post), nil
+}
+
+// Comment is the resolver for the comment field.
+func (r *savedItemResolver) Comment(ctx context.Context, obj *model.SavedItem) (*model.Comment, error) {
+	if obj.Type != model.SavedTypeComment {
+		return nil, nil
+	}
+	id, _ := strconv.Atoi(obj.ItemID) // id comes from already converted domain saved item
+
+	comment, err := r.loaders(ctx).Comment.Load(ctx, id)()
+	if errors.Is(err, errs.CommentNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		slog.Error("failed to load saved comment", "id", id, "error", err)
+		return nil, errs.InternalServer
+	}
+
+	return converter.Comment_DomainToModel(comment), nil
+}
+
+// NewComment is the resolver for the newComment field.
+func (r *subscriptionResolver) NewComment(ctx context.Context, postID string) (<-chan *model.Comment, error) {
+	domainPostID, err := strconv.Atoi(postID)
+	if err != nil {
+		return nil, errs.InvalidInputWrap(errs.InvalidIDField("postID"))
+	}
+
+	ch := make(chan *model.Comment, 10)
+
+	r.subscriptionService.SubscribeToPost(domainPostID, ch)
+	go func() {
+		<-ctx.Done()
+		r.subscriptionService.UnsubscribeFromPost(domainPostID, ch)
+		slog.Debug("subscription closed", "postID", domainPostID)
+	}()
+
+	return ch, nil
+}
+
+// Comment returns CommentResolver implementation.
+func (r *Resolver) Comment() CommentResolver { return &commentResolver{r} }
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Post returns PostResolver implementation.
+func (r *Resolver) Post() PostResolver { return &postResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Report returns ReportResolver implementation.
+func (r *Resolver) Report() ReportResolver { return &reportResolver{r} }
+
+// SavedItem returns SavedItemResolver implementation.
+func (r *Resolver) SavedItem() SavedItemResolver { return &savedItemResolver{r} }
 
 // Subscription returns SubscriptionResolver implementation.
 func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
@@ -303,4 +1217,6 @@ type commentResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type postResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type reportResolver struct{ *Resolver }
+type savedItemResolver struct{ *Resolver }
 type subscriptionResolver struct{ *Resolver }
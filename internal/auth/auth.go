@@ -0,0 +1,100 @@
+// Package auth resolves identity of the caller and passes it through request context
+package auth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// UserIDHeader carries id of authenticated user, set by gateway in front of the service.
+	UserIDHeader = "X-User-ID"
+	// ClientIPHeader carries address of the client, set by gateway in front of the service.
+	// It is trusted only from trusted proxies, any client could set it otherwise.
+	ClientIPHeader = "X-Real-IP"
+)
+
+type userIDKey struct{}
+type clientIPKey struct{}
+
+// Middleware puts user id from UserIDHeader and client ip into request context.
+// Requests without UserIDHeader are served anonymously.
+func Middleware(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := WithClientIP(r.Context(), clientIP(r, trustedProxies))
+
+			header := r.Header.Get(UserIDHeader)
+			if header == "" {
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			userID, err := uuid.Parse(header)
+			if err != nil {
+				http.Error(w, "invalid "+UserIDHeader+" header", http.StatusBadRequest)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithUserID(ctx, userID)))
+		})
+	}
+}
+
+// clientIP returns remote address of connection, or address reported by gateway when connection comes from trusted proxy.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	remote, err := netip.ParseAddr(host)
+	if err != nil || !trusted(remote.Unmap(), trustedProxies) {
+		return host
+	}
+	if ip, err := netip.ParseAddr(r.Header.Get(ClientIPHeader)); err == nil {
+		return ip.Unmap().String()
+	}
+	return host
+}
+
+func trusted(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func WithUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserID returns id of the caller, ok is false for anonymous requests.
+func UserID(ctx context.Context) (userID uuid.UUID, ok bool) {
+	userID, ok = ctx.Value(userIDKey{}).(uuid.UUID)
+	return userID, ok
+}
+
+// ViewerID returns id of the caller, nil for anonymous requests.
+func ViewerID(ctx context.Context) *uuid.UUID {
+	if userID, ok := UserID(ctx); ok {
+		return &userID
+	}
+	return nil
+}
+
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP returns address of the caller, empty if unknown.
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
@@ -3,9 +3,11 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"net/netip"
 	"time"
 
 	"github.com/caarlos0/env/v6"
+	"github.com/google/uuid"
 )
 
 type Config struct {
@@ -13,14 +15,95 @@ type Config struct {
 	Address         string        `env:"APP_ADDRESS,required"`
 	StorageType     string        `env:"STORAGE_TYPE,required"`
 	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT,required"`
-	Graphql         QraphqlConfig
-	DB              *DBConfig
+	// Users with admin role, they appoint community moderators
+	Admins []uuid.UUID `env:"APP_ADMINS" envSeparator:","`
+	// Networks of gateways whose X-Real-IP header is trusted, in CIDR notation
+	TrustedProxies []netip.Prefix `env:"APP_TRUSTED_PROXIES" envSeparator:","`
+	Graphql        QraphqlConfig
+	RateLimit      RateLimitConfig
+	Text           TextConfig
+	Limits         LimitsConfig
+	Retention      RetentionConfig
+	Preview        PreviewConfig
+	Idempotency    IdempotencyConfig
+	// How long ago link post counts as duplicate of new post of the same link in community
+	DuplicateLinkWindow time.Duration `env:"DUPLICATE_LINK_WINDOW" envDefault:"720h"`
+	// Number of post and comment revisions whose rendered HTML is kept in memory
+	MarkdownCacheSize int `env:"MARKDOWN_CACHE_SIZE" envDefault:"10000"`
+	DB                *DBConfig
 }
 
 type QraphqlConfig struct {
 	QueryCache              int  `env:"GRAPHQL_QUERY_CACHE,required"`
 	AutomaticPersistedQuery int  `env:"GRAPHQL_AUTOMATIC_PERSISTED_QUERY,required"`
 	Playground              bool `env:"GRAPHQL_PLAYGROUND,required"`
+	// Operations exceeding limits are rejected before execution
+	MaxComplexity int `env:"GRAPHQL_MAX_COMPLEXITY" envDefault:"5000"`
+	MaxDepth      int `env:"GRAPHQL_MAX_DEPTH" envDefault:"15"`
+}
+
+// RateLimitConfig sets token buckets per user (or ip for anonymous callers):
+// one action is restored every interval up to burst actions. Zero interval disables limit.
+type RateLimitConfig struct {
+	CreatePostInterval    time.Duration `env:"RATE_LIMIT_CREATE_POST_INTERVAL" envDefault:"30s"`
+	CreatePostBurst       int           `env:"RATE_LIMIT_CREATE_POST_BURST" envDefault:"5"`
+	CreateCommentInterval time.Duration `env:"RATE_LIMIT_CREATE_COMMENT_INTERVAL" envDefault:"5s"`
+	CreateCommentBurst    int           `env:"RATE_LIMIT_CREATE_COMMENT_BURST" envDefault:"20"`
+	VoteInterval          time.Duration `env:"RATE_LIMIT_VOTE_INTERVAL" envDefault:"500ms"`
+	VoteBurst             int           `env:"RATE_LIMIT_VOTE_BURST" envDefault:"60"`
+	// Buckets that refilled completely are deleted every purge interval
+	PurgeInterval time.Duration `env:"RATE_LIMIT_PURGE_INTERVAL" envDefault:"10m"`
+}
+
+// TextConfig controls normalization of titles, post content and comments before validation.
+// Length unit is either GRAPHEME (user-perceived characters) or RUNE.
+type TextConfig struct {
+	LengthUnit      string `env:"TEXT_LENGTH_UNIT" envDefault:"GRAPHEME"`
+	NormalizeNFC    bool   `env:"TEXT_NORMALIZE_NFC" envDefault:"true"`
+	TrimSpace       bool   `env:"TEXT_TRIM_SPACE" envDefault:"true"`
+	RejectInvisible bool   `env:"TEXT_REJECT_INVISIBLE" envDefault:"true"`
+	RejectControl   bool   `env:"TEXT_REJECT_CONTROL" envDefault:"true"`
+}
+
+// LimitsConfig bounds sizes of user content and pages, text lengths are counted in TEXT_LENGTH_UNIT.
+type LimitsConfig struct {
+	MaxTitleLen        int   `env:"LIMIT_TITLE_LENGTH" envDefault:"200"`
+	MaxContentLen      int   `env:"LIMIT_POST_CONTENT_LENGTH" envDefault:"20000"`
+	MaxCommentLen      int   `env:"LIMIT_COMMENT_LENGTH" envDefault:"2000"`
+	MaxReportLen       int   `env:"LIMIT_REPORT_LENGTH" envDefault:"1000"`
+	MaxPostsPerPage    int32 `env:"LIMIT_POSTS_PER_PAGE" envDefault:"100"`
+	MaxCommentsPerPage int32 `env:"LIMIT_COMMENTS_PER_PAGE" envDefault:"100"`
+	// Pages of moderation queue and moderation log
+	MaxReportsPerPage int32 `env:"LIMIT_REPORTS_PER_PAGE" envDefault:"100"`
+	MaxModLogPerPage  int32 `env:"LIMIT_MOD_LOG_PER_PAGE" envDefault:"100"`
+	// Levels of comment tree single comments, children or parentTree field may expand
+	MaxCommentDepth int32 `env:"LIMIT_COMMENT_DEPTH" envDefault:"10"`
+}
+
+// RetentionConfig sets how long deleted posts stay restorable before purge job deletes them permanently.
+type RetentionConfig struct {
+	DeletedPosts  time.Duration `env:"RETENTION_DELETED_POSTS" envDefault:"720h"`
+	PurgeInterval time.Duration `env:"RETENTION_PURGE_INTERVAL" envDefault:"1h"`
+}
+
+// PreviewConfig controls background fetching of link previews. Preview is shared by posts of the same link,
+// new posts get it fetched again once it is older than TTL.
+type PreviewConfig struct {
+	Timeout  time.Duration `env:"PREVIEW_TIMEOUT" envDefault:"5s"`
+	MaxBytes int64         `env:"PREVIEW_MAX_BYTES" envDefault:"1048576"`
+	TTL      time.Duration `env:"PREVIEW_TTL" envDefault:"24h"`
+	// Links are also looked for right after link post is made
+	Interval time.Duration `env:"PREVIEW_INTERVAL" envDefault:"1m"`
+	Workers  int           `env:"PREVIEW_WORKERS" envDefault:"4"`
+}
+
+type IdempotencyConfig struct {
+	// How long results of create operations are returned to retries with the same idempotency key
+	TTL time.Duration `env:"IDEMPOTENCY_TTL" envDefault:"24h"`
+	// How long key stays reserved by request that has not finished, retries with the key fail meanwhile.
+	// It should exceed duration of the longest create request, retry after it creates item again.
+	InProgressTimeout time.Duration `env:"IDEMPOTENCY_IN_PROGRESS_TIMEOUT" envDefault:"1m"`
+	PurgeInterval     time.Duration `env:"IDEMPOTENCY_PURGE_INTERVAL" envDefault:"1h"`
 }
 
 type DBConfig struct {
@@ -46,5 +129,77 @@ func Load() (Config, error) {
 		cfg.DB = &dbCfg
 	}
 
+	if cfg.Text.LengthUnit != "GRAPHEME" && cfg.Text.LengthUnit != "RUNE" {
+		return Config{}, fmt.Errorf("unknown text length unit %q", cfg.Text.LengthUnit)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return Config{}, err
+	}
+
 	return cfg, nil
 }
+
+// validate rejects settings that would make tickers panic, background jobs misbehave or rate limits deny everything.
+// Rate limit intervals may be zero because zero disables limit.
+func (cfg *Config) validate() error {
+	positive := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"SHUTDOWN_TIMEOUT", cfg.ShutdownTimeout},
+		{"RATE_LIMIT_PURGE_INTERVAL", cfg.RateLimit.PurgeInterval},
+		{"RETENTION_PURGE_INTERVAL", cfg.Retention.PurgeInterval},
+		{"PREVIEW_TIMEOUT", cfg.Preview.Timeout},
+		{"PREVIEW_TTL", cfg.Preview.TTL},
+		{"PREVIEW_INTERVAL", cfg.Preview.Interval},
+		{"IDEMPOTENCY_TTL", cfg.Idempotency.TTL},
+		{"IDEMPOTENCY_IN_PROGRESS_TIMEOUT", cfg.Idempotency.InProgressTimeout},
+		{"IDEMPOTENCY_PURGE_INTERVAL", cfg.Idempotency.PurgeInterval},
+	}
+	for _, d := range positive {
+		if d.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", d.name, d.value)
+		}
+	}
+
+	nonNegative := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"RATE_LIMIT_CREATE_POST_INTERVAL", cfg.RateLimit.CreatePostInterval},
+		{"RATE_LIMIT_CREATE_COMMENT_INTERVAL", cfg.RateLimit.CreateCommentInterval},
+		{"RATE_LIMIT_VOTE_INTERVAL", cfg.RateLimit.VoteInterval},
+		{"RETENTION_DELETED_POSTS", cfg.Retention.DeletedPosts},
+		{"DUPLICATE_LINK_WINDOW", cfg.DuplicateLinkWindow},
+	}
+	for _, d := range nonNegative {
+		if d.value < 0 {
+			return fmt.Errorf("%s must not be negative, got %s", d.name, d.value)
+		}
+	}
+
+	// Enabled limit with empty bucket would deny every action
+	bursts := []struct {
+		name     string
+		interval time.Duration
+		burst    int
+	}{
+		{"RATE_LIMIT_CREATE_POST_BURST", cfg.RateLimit.CreatePostInterval, cfg.RateLimit.CreatePostBurst},
+		{"RATE_LIMIT_CREATE_COMMENT_BURST", cfg.RateLimit.CreateCommentInterval, cfg.RateLimit.CreateCommentBurst},
+		{"RATE_LIMIT_VOTE_BURST", cfg.RateLimit.VoteInterval, cfg.RateLimit.VoteBurst},
+	}
+	for _, b := range bursts {
+		if b.interval > 0 && b.burst < 1 {
+			return fmt.Errorf("%s must be at least 1 when its interval is set, got %d", b.name, b.burst)
+		}
+	}
+
+	if cfg.Preview.Workers <= 0 {
+		return fmt.Errorf("PREVIEW_WORKERS must be positive, got %d", cfg.Preview.Workers)
+	}
+	if cfg.Preview.MaxBytes <= 0 {
+		return fmt.Errorf("PREVIEW_MAX_BYTES must be positive, got %d", cfg.Preview.MaxBytes)
+	}
+	return nil
+}
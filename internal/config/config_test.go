@@ -0,0 +1,66 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func setRequired(t *testing.T) {
+	t.Setenv("APP_ADDRESS", ":8080")
+	t.Setenv("STORAGE_TYPE", "INMEMORY")
+	t.Setenv("SHUTDOWN_TIMEOUT", "5s")
+	t.Setenv("GRAPHQL_QUERY_CACHE", "100")
+	t.Setenv("GRAPHQL_AUTOMATIC_PERSISTED_QUERY", "100")
+	t.Setenv("GRAPHQL_PLAYGROUND", "false")
+}
+
+func TestLoadDefaults(t *testing.T) {
+	setRequired(t)
+
+	if _, err := Load(); err != nil {
+		t.Fatalf("Load() with defaults failed: %v", err)
+	}
+}
+
+func TestLoadRejectsInvalidJobSettings(t *testing.T) {
+	tests := []struct {
+		env   string
+		value string
+	}{
+		{"RETENTION_PURGE_INTERVAL", "0s"},
+		{"RATE_LIMIT_PURGE_INTERVAL", "-1m"},
+		{"IDEMPOTENCY_PURGE_INTERVAL", "0s"},
+		{"IDEMPOTENCY_TTL", "0s"},
+		{"PREVIEW_INTERVAL", "0s"},
+		{"PREVIEW_TTL", "-1h"},
+		{"PREVIEW_TIMEOUT", "0s"},
+		{"PREVIEW_WORKERS", "0"},
+		{"PREVIEW_MAX_BYTES", "0"},
+		{"RATE_LIMIT_VOTE_INTERVAL", "-1s"},
+		{"RETENTION_DELETED_POSTS", "-1h"},
+		{"RATE_LIMIT_CREATE_POST_BURST", "0"},
+		{"RATE_LIMIT_VOTE_BURST", "-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			setRequired(t)
+			t.Setenv(tt.env, tt.value)
+
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), tt.env) {
+				t.Fatalf("Load() error = %v, want error about %s", err, tt.env)
+			}
+		})
+	}
+}
+
+func TestLoadAllowsDisabledRateLimit(t *testing.T) {
+	setRequired(t)
+	t.Setenv("RATE_LIMIT_VOTE_INTERVAL", "0s")
+	// Burst of disabled limit is never used
+	t.Setenv("RATE_LIMIT_VOTE_BURST", "0")
+
+	if _, err := Load(); err != nil {
+		t.Fatalf("Load() with zero rate limit interval failed: %v", err)
+	}
+}
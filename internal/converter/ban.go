@@ -0,0 +1,34 @@
+package converter
+
+import (
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func Ban_DomainToModel(d *domain.Ban) *model.Ban {
+	return &model.Ban{
+		Community: d.Community,
+		UserID:    d.UserID,
+		Reason:    d.Reason,
+		BannedBy:  d.BannedBy,
+		CreatedAt: d.CreatedAt,
+		ExpiresAt: d.ExpiresAt,
+	}
+}
+
+func Bans_DomainToModel(d []*domain.Ban) []*model.Ban {
+	bans := make([]*model.Ban, len(d))
+	for i, b := range d {
+		bans[i] = Ban_DomainToModel(b)
+	}
+	return bans
+}
+
+func BanInput_ModelToDomain(m *model.BanInput) *domain.BanInput {
+	return &domain.BanInput{
+		Community: m.Community,
+		UserID:    m.UserID,
+		Reason:    m.Reason,
+		ExpiresAt: m.ExpiresAt,
+	}
+}
@@ -9,27 +9,71 @@ import (
 
 func Comment_DomainToModel(d *domain.Comment) *model.Comment {
 	m := &model.Comment{
-		ID:        strconv.Itoa(d.ID),
-		PostID:    strconv.Itoa(d.PostID),
-		AuthorID:  d.AuthorID,
-		CreatedAt: d.CreatedAt,
-		Rating:    d.Rating,
-		ParentID:  nil,
+		ID:            strconv.Itoa(d.ID),
+		PostID:        strconv.Itoa(d.PostID),
+		AuthorID:      d.AuthorID,
+		CreatedAt:     d.CreatedAt,
+		Rating:        d.Rating,
+		Upvotes:       d.Upvotes,
+		Downvotes:     d.Downvotes,
+		Deleted:       d.Deleted,
+		Removed:       d.Removed(),
+		RemovedBy:     d.RemovedBy,
+		RemovedReason: d.RemovedReason,
+		RemovedAt:     d.RemovedAt,
+		EditedAt:      d.EditedAt,
+		Version:       d.Version,
+		ParentID:      nil,
 	}
 
-	if d.Text != nil {
+	switch {
+	case d.Deleted:
+		m.Text = DeletedPlaceholder
+	case d.Removed():
+		m.Text = RemovedPlaceholder
+	case d.Text != nil:
 		m.Text = *d.Text
 	}
+	if d.ParentID != nil {
+		parentID := strconv.Itoa(*d.ParentID)
+		m.ParentID = &parentID
+	}
 	return m
 }
 
+func CommentConnection_DomainToModel(d *domain.CommentConnection) *model.CommentConnection {
+	edges := make([]*model.CommentEdge, len(d.Edges))
+	for i, e := range d.Edges {
+		edges[i] = &model.CommentEdge{
+			Cursor: *e.Cursor,
+			Node:   Comment_DomainToModel(e.Comment),
+		}
+	}
+
+	return &model.CommentConnection{
+		Edges:    edges,
+		PageInfo: pageInfo_DomainToModel(d.PageInfo),
+	}
+}
+
+func CommentsInput(postID int, parentID *int, sort model.SortOrder, limit int32, cursor *string) *domain.CommentsInput {
+	return &domain.CommentsInput{
+		PostID:   postID,
+		ParentID: parentID,
+		Sort:     domain.SortOrder(sort),
+		Limit:    limit,
+		Cursor:   cursor,
+	}
+}
+
 func CreateCommentInput_ModelToDomain(m *model.CreateCommentInput) *domain.CreateCommentInput {
 	postID, _ := strconv.Atoi(m.PostID)
 	d := &domain.CreateCommentInput{
-		PostID:   postID,
-		AuthorID: m.AuthorID,
-		Text:     m.Text,
-		ParentID: nil,
+		PostID:         postID,
+		AuthorID:       m.AuthorID,
+		Text:           m.Text,
+		ParentID:       nil,
+		IdempotencyKey: m.IdempotencyKey,
 	}
 	if m.ParentID != nil {
 		parentID, _ := strconv.Atoi(*m.ParentID)
@@ -41,8 +85,9 @@ func CreateCommentInput_ModelToDomain(m *model.CreateCommentInput) *domain.Creat
 func UpdateCommentInput_ModelToDomain(m *model.UpdateCommentInput) *domain.UpdateCommentInput {
 	id, _ := strconv.Atoi(m.ID) // id already validated
 	return &domain.UpdateCommentInput{
-		ID:   id,
-		Text: m.Text,
+		ID:              id,
+		Text:            m.Text,
+		ExpectedVersion: m.ExpectedVersion,
 	}
 }
 
@@ -5,9 +5,24 @@ import (
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
 )
 
+// Placeholders replace text of content hidden from clients.
+const (
+	DeletedPlaceholder = "[deleted]"
+	RemovedPlaceholder = "[removed by moderator]"
+)
+
 func pageInfo_DomainToModel(d *domain.PageInfo) *model.PageInfo {
 	return &model.PageInfo{
 		HasNextPage: d.HasNext,
 		EndCursor:   d.EndCursor,
 	}
 }
+
+// MyVote_DomainToModel maps absent vote to null.
+func MyVote_DomainToModel(v int8) *int32 {
+	if v == 0 {
+		return nil
+	}
+	m := int32(v)
+	return &m
+}
@@ -0,0 +1,62 @@
+package converter
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func ModLogEntry_DomainToModel(d *domain.ModLogEntry) *model.ModLogEntry {
+	return &model.ModLogEntry{
+		ID:         strconv.Itoa(d.ID),
+		Community:  d.Community,
+		ActorID:    d.ActorID,
+		Action:     model.ModLogAction(d.Action),
+		TargetType: model.ModLogTargetType(d.TargetType),
+		TargetID:   d.TargetID,
+		Before:     state_DomainToModel(d.Before),
+		After:      state_DomainToModel(d.After),
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+func ModLogConnection_DomainToModel(d *domain.ModLogConnection) *model.ModLogConnection {
+	edges := make([]*model.ModLogEdge, len(d.Edges))
+	for i, e := range d.Edges {
+		edges[i] = &model.ModLogEdge{
+			Cursor: *e.Cursor,
+			Node:   ModLogEntry_DomainToModel(e.Entry),
+		}
+	}
+
+	return &model.ModLogConnection{
+		Edges:    edges,
+		PageInfo: pageInfo_DomainToModel(d.PageInfo),
+	}
+}
+
+func ModLogInput(community *string, actorID *uuid.UUID, action *model.ModLogAction, limit int32, cursor *string) *domain.ModLogInput {
+	d := &domain.ModLogInput{
+		Community: community,
+		ActorID:   actorID,
+		Limit:     limit,
+		Cursor:    cursor,
+	}
+	if action != nil {
+		a := domain.ModLogAction(*action)
+		d.Action = &a
+	}
+	return d
+}
+
+// state_DomainToModel maps absent state to null.
+func state_DomainToModel(d []byte) *string {
+	if d == nil {
+		return nil
+	}
+	s := string(d)
+	return &s
+}
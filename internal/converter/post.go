@@ -8,32 +8,62 @@ import (
 )
 
 func Post_DomainToModel(d *domain.Post) *model.Post {
-	return &model.Post{
+	m := &model.Post{
 		ID:                 strconv.Itoa(d.ID),
 		AuthorID:           d.AuthorID,
+		Community:          d.Community,
 		Title:              d.Title,
 		Content:            d.Content,
+		Kind:               model.PostKind(d.Kind),
+		URL:                d.URL,
+		Domain:             d.Domain,
 		CreatedAt:          d.CreatedAt,
 		Rating:             d.Rating,
+		Upvotes:            d.Upvotes,
+		Downvotes:          d.Downvotes,
 		CommentsCount:      d.CommentsCount,
 		CommentsRestricted: d.CommentsRestricted,
+		Deleted:            d.Deleted,
+		Removed:            d.Removed(),
+		RemovedBy:          d.RemovedBy,
+		RemovedReason:      d.RemovedReason,
+		RemovedAt:          d.RemovedAt,
+		EditedAt:           d.EditedAt,
+		Version:            d.Version,
 	}
+
+	switch {
+	case d.Deleted:
+		m.Title = DeletedPlaceholder
+		m.Content = DeletedPlaceholder
+		m.URL, m.Domain = nil, nil
+	case d.Removed():
+		m.Title = RemovedPlaceholder
+		m.Content = RemovedPlaceholder
+		m.URL, m.Domain = nil, nil
+	}
+	return m
 }
 
 func CreatePostInput_ModelToDomain(m *model.CreatePostInput) *domain.CreatePostInput {
 	return &domain.CreatePostInput{
-		AuthorID: m.AuthorID,
-		Title:    m.Title,
-		Content:  m.Content,
+		AuthorID:       m.AuthorID,
+		Community:      m.Community,
+		Title:          m.Title,
+		Content:        m.Content,
+		Kind:           domain.PostKind(m.Kind),
+		URL:            m.URL,
+		IdempotencyKey: m.IdempotencyKey,
 	}
 }
 
 func UpdatePost_ModelToDomain(m *model.UpdatePostInput) *domain.UpdatePostInput {
 	id, _ := strconv.Atoi(m.ID) // id already validated
 	return &domain.UpdatePostInput{
-		ID:      id,
-		Title:   m.Title,
-		Content: m.Content,
+		ID:              id,
+		Title:           m.Title,
+		Content:         m.Content,
+		ExpectedVersion: m.ExpectedVersion,
 	}
 }
 
@@ -70,3 +100,17 @@ func PostsInput(sort model.SortOrder, limit int32, cursor *string) *domain.Posts
 		Cursor: cursor,
 	}
 }
+
+func PostsByDomainInput(postDomain string, sort model.SortOrder, limit int32, cursor *string) *domain.PostsInput {
+	in := PostsInput(sort, limit, cursor)
+	in.Filter.Domain = &postDomain
+	return in
+}
+
+func Posts_DomainToModel(posts []*domain.Post) []*model.Post {
+	m := make([]*model.Post, len(posts))
+	for i, p := range posts {
+		m[i] = Post_DomainToModel(p)
+	}
+	return m
+}
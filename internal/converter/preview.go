@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+// LinkPreview_DomainToModel returns nil for missing previews and previews of pages that could not be fetched.
+func LinkPreview_DomainToModel(d *domain.LinkPreview) *model.LinkPreview {
+	if d == nil || d.Status != domain.LinkPreviewReady {
+		return nil
+	}
+	return &model.LinkPreview{
+		Title:       d.Title,
+		Description: d.Description,
+		ImageURL:    d.ImageURL,
+		FetchedAt:   d.FetchedAt,
+	}
+}
@@ -0,0 +1,61 @@
+package converter
+
+import (
+	"strconv"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func Report_DomainToModel(d *domain.Report) *model.Report {
+	m := &model.Report{
+		ID:         strconv.Itoa(d.ID),
+		PostID:     strconv.Itoa(d.PostID),
+		CommentID:  nil,
+		Community:  d.Community,
+		ReporterID: d.ReporterID,
+		Reason:     model.ReportReason(d.Reason),
+		Text:       d.Text,
+		Status:     model.ReportStatus(d.Status),
+		CreatedAt:  d.CreatedAt,
+		ResolvedBy: d.ResolvedBy,
+		ResolvedAt: d.ResolvedAt,
+	}
+	if d.CommentID != nil {
+		commentID := strconv.Itoa(*d.CommentID)
+		m.CommentID = &commentID
+	}
+	return m
+}
+
+func ReportConnection_DomainToModel(d *domain.ReportConnection) *model.ReportConnection {
+	edges := make([]*model.ReportEdge, len(d.Edges))
+	for i, e := range d.Edges {
+		edges[i] = &model.ReportEdge{
+			Cursor: *e.Cursor,
+			Node:   Report_DomainToModel(e.Report),
+		}
+	}
+
+	return &model.ReportConnection{
+		Edges:    edges,
+		PageInfo: pageInfo_DomainToModel(d.PageInfo),
+	}
+}
+
+func ModerationQueueInput(community string, status model.ReportStatus, limit int32, cursor *string) *domain.ModerationQueueInput {
+	return &domain.ModerationQueueInput{
+		Community: community,
+		Status:    domain.ReportStatus(status),
+		Limit:     limit,
+		Cursor:    cursor,
+	}
+}
+
+func ReportReason_ModelToDomain(m model.ReportReason) domain.ReportReason {
+	return domain.ReportReason(m)
+}
+
+func ReportAction_ModelToDomain(m model.ReportAction) domain.ReportAction {
+	return domain.ReportAction(m)
+}
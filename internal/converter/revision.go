@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func PostRevisions_DomainToModel(d []*domain.PostRevision) []*model.PostRevision {
+	revisions := make([]*model.PostRevision, len(d))
+	for i, r := range d {
+		revisions[i] = &model.PostRevision{
+			Number:   int32(r.Number),
+			Title:    r.Title,
+			Content:  r.Content,
+			EditorID: r.EditorID,
+			EditedAt: r.EditedAt,
+		}
+	}
+	return revisions
+}
+
+func CommentRevisions_DomainToModel(d []*domain.CommentRevision) []*model.CommentRevision {
+	revisions := make([]*model.CommentRevision, len(d))
+	for i, r := range d {
+		revisions[i] = &model.CommentRevision{
+			Number:   int32(r.Number),
+			Text:     r.Text,
+			EditorID: r.EditorID,
+			EditedAt: r.EditedAt,
+		}
+	}
+	return revisions
+}
+
+// RevisionRange maps version numbers of diff, nil to stays nil.
+func RevisionRange(from int32, to *int32) (int, *int) {
+	if to == nil {
+		return int(from), nil
+	}
+	domainTo := int(*to)
+	return int(from), &domainTo
+}
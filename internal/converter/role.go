@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func Moderator_DomainToModel(d *domain.Moderator) *model.Moderator {
+	return &model.Moderator{
+		Community: d.Community,
+		UserID:    d.UserID,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+func Moderators_DomainToModel(d []*domain.Moderator) []*model.Moderator {
+	mods := make([]*model.Moderator, len(d))
+	for i, mod := range d {
+		mods[i] = Moderator_DomainToModel(mod)
+	}
+	return mods
+}
+
+// Role_DomainToModel returns nil for empty role.
+func Role_DomainToModel(d domain.Role) *model.Role {
+	if d == "" {
+		return nil
+	}
+	role := model.Role(d)
+	return &role
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"strconv"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func SavedInput(savedType model.SavedType, limit int32, cursor *string) *domain.SavedInput {
+	return &domain.SavedInput{
+		Type:   domain.SavedType(savedType),
+		Limit:  limit,
+		Cursor: cursor,
+	}
+}
+
+func SavedItem_DomainToModel(d *domain.SavedItem) *model.SavedItem {
+	return &model.SavedItem{
+		Type:    model.SavedType(d.Type),
+		ItemID:  strconv.Itoa(d.ItemID),
+		SavedAt: d.SavedAt,
+	}
+}
+
+func SavedConnection_DomainToModel(d *domain.SavedConnection) *model.SavedConnection {
+	edges := make([]*model.SavedEdge, len(d.Edges))
+	for i, e := range d.Edges {
+		edges[i] = &model.SavedEdge{
+			Cursor: *e.Cursor,
+			Node:   SavedItem_DomainToModel(e.Item),
+		}
+	}
+
+	return &model.SavedConnection{
+		Edges:    edges,
+		PageInfo: pageInfo_DomainToModel(d.PageInfo),
+	}
+}
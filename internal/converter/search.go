@@ -0,0 +1,45 @@
+package converter
+
+import (
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func SearchInput(query string, searchType model.SearchType, sort model.SearchSort, limit int32, cursor *string) *domain.SearchInput {
+	return &domain.SearchInput{
+		Query:  query,
+		Type:   domain.SearchType(searchType),
+		Sort:   domain.SearchSort(sort),
+		Limit:  limit,
+		Cursor: cursor,
+	}
+}
+
+func SearchResult_DomainToModel(d *domain.SearchHit) *model.SearchResult {
+	m := &model.SearchResult{
+		Rank:    d.Rank,
+		Snippet: d.Snippet,
+	}
+	if d.Post != nil {
+		m.Post = Post_DomainToModel(d.Post)
+	}
+	if d.Comment != nil {
+		m.Comment = Comment_DomainToModel(d.Comment)
+	}
+	return m
+}
+
+func SearchConnection_DomainToModel(d *domain.SearchConnection) *model.SearchConnection {
+	edges := make([]*model.SearchEdge, len(d.Edges))
+	for i, e := range d.Edges {
+		edges[i] = &model.SearchEdge{
+			Cursor: *e.Cursor,
+			Node:   SearchResult_DomainToModel(e.Hit),
+		}
+	}
+
+	return &model.SearchConnection{
+		Edges:    edges,
+		PageInfo: pageInfo_DomainToModel(d.PageInfo),
+	}
+}
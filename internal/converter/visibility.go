@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func BlockedUser_DomainToModel(d *domain.Block) *model.BlockedUser {
+	return &model.BlockedUser{
+		UserID:    d.BlockedID,
+		BlockedAt: d.CreatedAt,
+	}
+}
+
+func BlockedUsers_DomainToModel(d []*domain.Block) []*model.BlockedUser {
+	users := make([]*model.BlockedUser, len(d))
+	for i, b := range d {
+		users[i] = BlockedUser_DomainToModel(b)
+	}
+	return users
+}
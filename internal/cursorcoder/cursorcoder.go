@@ -22,7 +22,7 @@ func DecodeTimeID(s string) (*domain.PostTimeCursor, error) {
 		return nil, err
 	}
 	if len(parts) != 2 {
-		return nil, err
+		return nil, fmt.Errorf("expected 2 cursor parts, got %d", len(parts))
 	}
 
 	t, err := time.Parse(time.RFC3339Nano, parts[0])
@@ -50,10 +50,10 @@ func DecodeRatingID(s string) (*domain.PostRatingCursor, error) {
 		return nil, err
 	}
 	if len(parts) != 2 {
-		return nil, err
+		return nil, fmt.Errorf("expected 2 cursor parts, got %d", len(parts))
 	}
 
-	r, err := strconv.Atoi(parts[0])
+	r, err := strconv.ParseInt(parts[0], 10, 32)
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +68,50 @@ func DecodeRatingID(s string) (*domain.PostRatingCursor, error) {
 	return cursor, nil
 }
 
+func EncodeScoreID(score float64, id int) string {
+	return encodeParts(strconv.FormatFloat(score, 'g', -1, 64), id)
+}
+
+func DecodeScoreID(s string) (*domain.CommentBestCursor, error) {
+	parts, err := decodeParts(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected 2 cursor parts, got %d", len(parts))
+	}
+
+	score, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return nil, err
+	}
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	cursor := &domain.CommentBestCursor{
+		Score: score,
+		ID:    id,
+	}
+	return cursor, nil
+}
+
+// EncodeID encodes cursor of listings ordered by id only.
+func EncodeID(id int) string {
+	return encodeParts(id)
+}
+
+func DecodeID(s string) (int, error) {
+	parts, err := decodeParts(s)
+	if err != nil {
+		return 0, err
+	}
+	if len(parts) != 1 {
+		return 0, fmt.Errorf("expected 1 cursor part, got %d", len(parts))
+	}
+	return strconv.Atoi(parts[0])
+}
+
 // encodeParts encodes arbitrary values as base64("val1|val2|...")
 func encodeParts(values ...any) string {
 	parts := make([]string, len(values))
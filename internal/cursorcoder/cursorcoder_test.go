@@ -0,0 +1,101 @@
+package cursorcoder
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/ranking"
+)
+
+func TestScoreIDRoundTrip(t *testing.T) {
+	scores := []float64{
+		0,
+		ranking.Wilson(1, 0),
+		ranking.Wilson(10, 2),
+		ranking.Wilson(1000, 1),
+		math.SmallestNonzeroFloat64,
+		math.Nextafter(0.5, 1),
+		1,
+	}
+	for _, score := range scores {
+		cursor, err := DecodeScoreID(EncodeScoreID(score, 42))
+		if err != nil {
+			t.Fatalf("DecodeScoreID(EncodeScoreID(%v)) error = %v", score, err)
+		}
+		// Keyset pagination compares exact scores, any rounding would skip or repeat comments
+		if cursor.Score != score || cursor.ID != 42 {
+			t.Errorf("round trip of (%v, 42) = (%v, %d)", score, cursor.Score, cursor.ID)
+		}
+	}
+}
+
+func TestDecodeScoreIDRejectsMalformed(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"not base64!",
+		EncodeID(1),
+		encodeParts("0.5", 1, 2),
+		encodeParts("score", 1),
+		encodeParts("0.5", "id"),
+	} {
+		if cursor, err := DecodeScoreID(s); err == nil {
+			t.Errorf("DecodeScoreID(%q) = %+v, want error", s, cursor)
+		}
+	}
+}
+
+func TestTimeIDRoundTrip(t *testing.T) {
+	want := time.Date(2024, 5, 17, 10, 30, 0, 123456789, time.UTC)
+	cursor, err := DecodeTimeID(EncodeTimeID(want, 42))
+	if err != nil {
+		t.Fatalf("DecodeTimeID(EncodeTimeID()) error = %v", err)
+	}
+	if !cursor.Time.Equal(want) || cursor.ID != 42 {
+		t.Errorf("round trip of (%v, 42) = (%v, %d)", want, cursor.Time, cursor.ID)
+	}
+}
+
+func TestDecodeTimeIDRejectsMalformed(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"not base64!",
+		EncodeID(1),
+		encodeParts("2024-05-17T10:30:00Z", 1, 2),
+		encodeParts("yesterday", 1),
+		encodeParts("2024-05-17T10:30:00Z", "id"),
+	} {
+		// Cursor must never be nil without error, services would silently start from the first page
+		if cursor, err := DecodeTimeID(s); err == nil {
+			t.Errorf("DecodeTimeID(%q) = %+v, want error", s, cursor)
+		}
+	}
+}
+
+func TestRatingIDRoundTrip(t *testing.T) {
+	for _, rating := range []int32{math.MinInt32, -1, 0, 7, math.MaxInt32} {
+		cursor, err := DecodeRatingID(EncodeRatingID(rating, 42))
+		if err != nil {
+			t.Fatalf("DecodeRatingID(EncodeRatingID(%d)) error = %v", rating, err)
+		}
+		if cursor.Rating != rating || cursor.ID != 42 {
+			t.Errorf("round trip of (%d, 42) = (%d, %d)", rating, cursor.Rating, cursor.ID)
+		}
+	}
+}
+
+func TestDecodeRatingIDRejectsMalformed(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"not base64!",
+		EncodeID(1),
+		encodeParts(7, 1, 2),
+		encodeParts("rating", 1),
+		encodeParts(7, "id"),
+		encodeParts(int64(math.MaxInt32)+1, 1),
+	} {
+		if cursor, err := DecodeRatingID(s); err == nil {
+			t.Errorf("DecodeRatingID(%q) = %+v, want error", s, cursor)
+		}
+	}
+}
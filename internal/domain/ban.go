@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ban stops user from posting, commenting and voting in community, or everywhere when Community is nil.
+type Ban struct {
+	Community *string    `db:"community"`
+	UserID    uuid.UUID  `db:"user_id"`
+	Reason    *string    `db:"reason"`
+	BannedBy  uuid.UUID  `db:"banned_by"`
+	CreatedAt time.Time  `db:"created_at"`
+	ExpiresAt *time.Time `db:"expires_at"`
+}
+
+// Active reports whether ban has not expired yet.
+func (b *Ban) Active(now time.Time) bool {
+	return b.ExpiresAt == nil || b.ExpiresAt.After(now)
+}
+
+type BanInput struct {
+	// Nil for global ban
+	Community *string
+	UserID    uuid.UUID
+	Reason    *string
+	// Nil for permanent ban
+	ExpiresAt *time.Time
+	BannedBy  uuid.UUID
+}
+
+// BanState is state of user targeted by ban captured by audit log.
+type BanState struct {
+	Banned    bool       `json:"banned"`
+	Reason    *string    `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// BanStateOf returns state of user under ban b, nil ban means user is not banned.
+func BanStateOf(b *Ban) *BanState {
+	if b == nil {
+		return &BanState{Banned: false}
+	}
+	return &BanState{Banned: true, Reason: b.Reason, ExpiresAt: b.ExpiresAt}
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Block hides content of BlockedID from UserID.
+type Block struct {
+	UserID    uuid.UUID `db:"user_id"`
+	BlockedID uuid.UUID `db:"blocked_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
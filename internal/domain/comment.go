@@ -15,6 +15,14 @@ type Comment struct {
 	Rating    int32     `db:"rating"`
 	Deleted   bool      `db:"deleted"`
 	ParentID  *int      `db:"parent_id"`
+	Upvotes   int32     `db:"upvotes"`
+	Downvotes int32     `db:"downvotes"`
+	// Lower bound of Wilson score interval, recalculated on every vote
+	BestScore float64    `db:"best_score"`
+	EditedAt  *time.Time `db:"edited_at"`
+	// Starts from 1 and grows with every edit
+	Version int32 `db:"version"`
+	Removal
 }
 
 type CreateCommentInput struct {
@@ -22,13 +30,55 @@ type CreateCommentInput struct {
 	AuthorID uuid.UUID
 	Text     string
 	ParentID *int
+	// Retried request with the same key returns comment created by the first one
+	IdempotencyKey *string
 }
 
 type UpdateCommentInput struct {
-	ID   int
-	Text string
+	ID       int
+	Text     string
+	EditorID uuid.UUID
+	// Edit fails with errs.Conflict if set and comment has different version
+	ExpectedVersion *int32
 }
 
 type CommentVote struct {
 	Vote
 }
+
+// CommentsInput requests top-level comments of a post when ParentID is nil
+// and direct replies of ParentID otherwise.
+type CommentsInput struct {
+	PostID   int
+	ParentID *int
+	Sort     SortOrder
+	Limit    int32
+	Cursor   *string
+}
+
+// CommentsParent identifies list of sibling comments:
+// top-level comments of post when ParentID is nil and direct replies of ParentID otherwise.
+type CommentsParent struct {
+	PostID   int
+	ParentID *int
+}
+
+type CommentEdge struct {
+	Cursor  *string
+	Comment *Comment
+}
+
+type CommentConnection struct {
+	Edges    []*CommentEdge
+	PageInfo *PageInfo
+}
+
+type CommentBestCursor struct {
+	Score float64
+	ID    int
+}
+
+type CommentsPage struct {
+	Comments []*Comment
+	HasNext  bool
+}
@@ -2,6 +2,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -11,6 +13,8 @@ const (
 	SortOrderRating SortOrder = "RATING"
 	SortOrderNew    SortOrder = "NEW"
 	SortOrderOld    SortOrder = "OLD"
+	// SortOrderBest orders comments by lower bound of Wilson score interval
+	SortOrderBest SortOrder = "BEST"
 )
 
 type Vote struct {
@@ -24,3 +28,20 @@ type PageInfo struct {
 	HasNext   bool
 	EndCursor *string
 }
+
+// Removal is set when moderator removes content, as opposed to deletion by author.
+type Removal struct {
+	RemovedBy     *uuid.UUID `db:"removed_by"`
+	RemovedReason *string    `db:"removed_reason"`
+	RemovedAt     *time.Time `db:"removed_at"`
+}
+
+func (r Removal) Removed() bool {
+	return r.RemovedAt != nil
+}
+
+type RemoveInput struct {
+	ID          int
+	ModeratorID uuid.UUID
+	Reason      *string
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IdempotencyOperation string
+
+const (
+	IdempotencyCreatePost    IdempotencyOperation = "CREATE_POST"
+	IdempotencyCreateComment IdempotencyOperation = "CREATE_COMMENT"
+)
+
+// IdempotencyKey is key client sent along with operation, it maps to id of item the operation created.
+// Keys are scoped by user and operation and forgotten once expired.
+type IdempotencyKey struct {
+	UserID    uuid.UUID            `db:"user_id"`
+	Operation IdempotencyOperation `db:"operation"`
+	Key       string               `db:"key"`
+	// Hash of operation input, the same key cannot be used with different input
+	Fingerprint string `db:"fingerprint"`
+	// Nil while operation is in progress
+	ResultID  *int      `db:"result_id"`
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ModLogAction string
+
+const (
+	ModLogActionRemovePost         ModLogAction = "REMOVE_POST"
+	ModLogActionApprovePost        ModLogAction = "APPROVE_POST"
+	ModLogActionDeletePost         ModLogAction = "DELETE_POST"
+	ModLogActionRestorePost        ModLogAction = "RESTORE_POST"
+	ModLogActionRestrictComments   ModLogAction = "RESTRICT_COMMENTS"
+	ModLogActionUnrestrictComments ModLogAction = "UNRESTRICT_COMMENTS"
+	ModLogActionRemoveComment      ModLogAction = "REMOVE_COMMENT"
+	ModLogActionApproveComment     ModLogAction = "APPROVE_COMMENT"
+	ModLogActionDeleteComment      ModLogAction = "DELETE_COMMENT"
+	ModLogActionResolveReport      ModLogAction = "RESOLVE_REPORT"
+	ModLogActionAddModerator       ModLogAction = "ADD_MODERATOR"
+	ModLogActionRemoveModerator    ModLogAction = "REMOVE_MODERATOR"
+	ModLogActionBanUser            ModLogAction = "BAN_USER"
+	ModLogActionUnbanUser          ModLogAction = "UNBAN_USER"
+)
+
+type ModLogTargetType string
+
+const (
+	ModLogTargetPost    ModLogTargetType = "POST"
+	ModLogTargetComment ModLogTargetType = "COMMENT"
+	ModLogTargetUser    ModLogTargetType = "USER"
+)
+
+// ModLogEntry records moderation or administrative action, entries are never changed.
+// Before and After hold JSON of target state around action.
+type ModLogEntry struct {
+	ID int `db:"id"`
+	// Nil for global actions
+	Community  *string          `db:"community"`
+	ActorID    uuid.UUID        `db:"actor_id"`
+	Action     ModLogAction     `db:"action"`
+	TargetType ModLogTargetType `db:"target_type"`
+	// Post or comment id, or user uuid
+	TargetID  string          `db:"target_id"`
+	Before    json.RawMessage `db:"before"`
+	After     json.RawMessage `db:"after"`
+	CreatedAt time.Time       `db:"created_at"`
+}
+
+// AddModLogEntryInput describes entry storage appends to audit log along with action it records.
+// Storage captures states of target before and after action itself, so that entry and action
+// are written atomically.
+type AddModLogEntryInput struct {
+	// Nil for global actions
+	Community  *string
+	ActorID    uuid.UUID
+	Action     ModLogAction
+	TargetType ModLogTargetType
+	TargetID   string
+}
+
+// ContentState is moderation state of post or comment captured by audit log.
+type ContentState struct {
+	Deleted       bool    `json:"deleted"`
+	Removed       bool    `json:"removed"`
+	RemovedReason *string `json:"removedReason,omitempty"`
+	// Set for posts only
+	CommentsRestricted *bool `json:"commentsRestricted,omitempty"`
+}
+
+func PostState(p *Post) *ContentState {
+	return &ContentState{
+		Deleted:            p.Deleted,
+		Removed:            p.Removed(),
+		RemovedReason:      p.RemovedReason,
+		CommentsRestricted: &p.CommentsRestricted,
+	}
+}
+
+func CommentState(c *Comment) *ContentState {
+	return &ContentState{
+		Deleted:       c.Deleted,
+		Removed:       c.Removed(),
+		RemovedReason: c.RemovedReason,
+	}
+}
+
+// ModeratorState is state of user targeted by moderator appointment.
+type ModeratorState struct {
+	Moderator bool `json:"moderator"`
+}
+
+// ReportState is state of reports of content targeted by report resolution.
+type ReportState struct {
+	ReportID int          `json:"reportID"`
+	Status   ReportStatus `json:"status"`
+}
+
+type ModLogInput struct {
+	// Nil for global log
+	Community *string
+	ActorID   *uuid.UUID
+	Action    *ModLogAction
+	Limit     int32
+	Cursor    *string
+}
+
+type ModLogFilter struct {
+	// Nil for global log
+	Community *string
+	ActorID   *uuid.UUID
+	Action    *ModLogAction
+}
+
+type ModLogEdge struct {
+	Cursor *string
+	Entry  *ModLogEntry
+}
+
+type ModLogConnection struct {
+	Edges    []*ModLogEdge
+	PageInfo *PageInfo
+}
+
+type ModLogPage struct {
+	Entries []*ModLogEntry
+	HasNext bool
+}
@@ -6,27 +6,61 @@ import (
 	"github.com/google/uuid"
 )
 
+type PostKind string
+
+const (
+	PostKindText PostKind = "TEXT"
+	// Link post points to URL, its content is optional
+	PostKindLink PostKind = "LINK"
+)
+
+// Post URL, Domain and NormalizedURL are set for link posts only. Domain is host of URL without www prefix,
+// links to the same page have the same NormalizedURL.
 type Post struct {
-	ID                 int       `db:"id"`
-	AuthorID           uuid.UUID `db:"author_id"`
-	Title              string    `db:"title"`
-	Content            string    `db:"content"`
-	CreatedAt          time.Time `db:"created_at"`
-	Rating             int32     `db:"rating"`
-	CommentsCount      int32     `db:"comments_count"`
-	CommentsRestricted bool      `db:"comments_restricted"`
+	ID                 int        `db:"id"`
+	AuthorID           uuid.UUID  `db:"author_id"`
+	Community          string     `db:"community"`
+	Title              string     `db:"title"`
+	Content            string     `db:"content"`
+	Kind               PostKind   `db:"kind"`
+	URL                *string    `db:"url"`
+	Domain             *string    `db:"domain"`
+	NormalizedURL      *string    `db:"normalized_url"`
+	CreatedAt          time.Time  `db:"created_at"`
+	Rating             int32      `db:"rating"`
+	CommentsCount      int32      `db:"comments_count"`
+	CommentsRestricted bool       `db:"comments_restricted"`
+	Upvotes            int32      `db:"upvotes"`
+	Downvotes          int32      `db:"downvotes"`
+	Deleted            bool       `db:"deleted"`
+	DeletedAt          *time.Time `db:"deleted_at"`
+	EditedAt           *time.Time `db:"edited_at"`
+	// Starts from 1 and grows with every edit
+	Version int32 `db:"version"`
+	Removal
 }
 
 type CreatePostInput struct {
-	AuthorID uuid.UUID
-	Title    string
-	Content  string
+	AuthorID  uuid.UUID
+	Community string
+	Title     string
+	Content   string
+	Kind      PostKind
+	// URL of link post, Domain and NormalizedURL are derived from it by service
+	URL           *string
+	Domain        *string
+	NormalizedURL *string
+	// Retried request with the same key returns post created by the first one
+	IdempotencyKey *string
 }
 
 type UpdatePostInput struct {
-	ID      int
-	Title   *string
-	Content *string
+	ID       int
+	Title    *string
+	Content  *string
+	EditorID uuid.UUID
+	// Edit fails with errs.Conflict if set and post has different version
+	ExpectedVersion *int32
 }
 
 type PostVote struct {
@@ -37,6 +71,13 @@ type PostsInput struct {
 	Sort   SortOrder
 	Limit  int32
 	Cursor *string
+	Filter PostsFilter
+}
+
+// PostsFilter narrows posts listing, nil fields match every post.
+type PostsFilter struct {
+	// Domain of link posts, removed posts are left out as their links are hidden
+	Domain *string
 }
 
 type PostEdge struct {
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+type LinkPreviewStatus string
+
+const (
+	LinkPreviewReady LinkPreviewStatus = "READY"
+	// Page could not be fetched, fetch is retried once preview gets stale
+	LinkPreviewFailed LinkPreviewStatus = "FAILED"
+)
+
+// LinkPreview is metadata of linked page, posts linking the same page share it.
+type LinkPreview struct {
+	NormalizedURL string            `db:"normalized_url"`
+	Status        LinkPreviewStatus `db:"status"`
+	Title         *string           `db:"title"`
+	Description   *string           `db:"description"`
+	ImageURL      *string           `db:"image_url"`
+	FetchedAt     time.Time         `db:"fetched_at"`
+}
+
+// Link is page of link posts that needs preview.
+type Link struct {
+	URL           string `db:"url"`
+	NormalizedURL string `db:"normalized_url"`
+}
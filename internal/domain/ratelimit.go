@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+// RateLimit describes token bucket refilling one token every Interval up to Burst tokens.
+type RateLimit struct {
+	Interval time.Duration
+	Burst    int
+}
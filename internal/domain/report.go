@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportReason string
+
+const (
+	ReportReasonSpam           ReportReason = "SPAM"
+	ReportReasonHarassment     ReportReason = "HARASSMENT"
+	ReportReasonHate           ReportReason = "HATE"
+	ReportReasonViolence       ReportReason = "VIOLENCE"
+	ReportReasonMisinformation ReportReason = "MISINFORMATION"
+	ReportReasonOther          ReportReason = "OTHER"
+)
+
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "OPEN"
+	ReportStatusApproved  ReportStatus = "APPROVED"
+	ReportStatusRemoved   ReportStatus = "REMOVED"
+	ReportStatusDismissed ReportStatus = "DISMISSED"
+)
+
+// ReportAction is decision of moderator on reported content.
+type ReportAction string
+
+const (
+	// ReportActionApprove keeps content as it does not break rules
+	ReportActionApprove ReportAction = "APPROVE"
+	// ReportActionRemove removes reported content
+	ReportActionRemove ReportAction = "REMOVE"
+	// ReportActionDismiss keeps content as report is not actionable
+	ReportActionDismiss ReportAction = "DISMISS"
+)
+
+// Report flags post, or comment of post when CommentID is set.
+type Report struct {
+	ID         int          `db:"id"`
+	PostID     int          `db:"post_id"`
+	CommentID  *int         `db:"comment_id"`
+	Community  string       `db:"community"`
+	ReporterID uuid.UUID    `db:"reporter_id"`
+	Reason     ReportReason `db:"reason"`
+	Text       *string      `db:"text"`
+	Status     ReportStatus `db:"status"`
+	CreatedAt  time.Time    `db:"created_at"`
+	ResolvedBy *uuid.UUID   `db:"resolved_by"`
+	ResolvedAt *time.Time   `db:"resolved_at"`
+}
+
+type CreateReportInput struct {
+	PostID     int
+	CommentID  *int
+	Community  string
+	ReporterID uuid.UUID
+	Reason     ReportReason
+	Text       *string
+}
+
+type ResolveReportInput struct {
+	ID         int
+	Status     ReportStatus
+	ResolverID uuid.UUID
+}
+
+type ModerationQueueInput struct {
+	Community string
+	Status    ReportStatus
+	Limit     int32
+	Cursor    *string
+}
+
+type ReportEdge struct {
+	Cursor *string
+	Report *Report
+}
+
+type ReportConnection struct {
+	Edges    []*ReportEdge
+	PageInfo *PageInfo
+}
+
+type ReportsPage struct {
+	Reports []*Report
+	HasNext bool
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostRevision is version of post replaced by edit. Revisions of post are numbered from 1 in order of edits,
+// so the current version of post has number following the last revision.
+type PostRevision struct {
+	Number   int       `db:"number"`
+	Title    string    `db:"title"`
+	Content  string    `db:"content"`
+	EditorID uuid.UUID `db:"editor_id"`
+	// When version was replaced
+	EditedAt time.Time `db:"edited_at"`
+}
+
+// CommentRevision is version of comment replaced by edit, numbered the same way as PostRevision.
+type CommentRevision struct {
+	Number   int       `db:"number"`
+	Text     string    `db:"text"`
+	EditorID uuid.UUID `db:"editor_id"`
+	// When version was replaced
+	EditedAt time.Time `db:"edited_at"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCommunity receives posts created without community.
+const DefaultCommunity = "general"
+
+// Role is permission level of user. Admins are global and set in config,
+// moderators are appointed per community.
+type Role string
+
+const (
+	RoleAdmin     Role = "ADMIN"
+	RoleModerator Role = "MODERATOR"
+)
+
+// Outranks reports whether role r is stronger than other, empty role of regular user is the weakest.
+func (r Role) Outranks(other Role) bool {
+	return r.rank() > other.rank()
+}
+
+func (r Role) rank() int {
+	switch r {
+	case RoleAdmin:
+		return 2
+	case RoleModerator:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type Moderator struct {
+	Community string    `db:"community"`
+	UserID    uuid.UUID `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SavedType string
+
+const (
+	SavedTypePost    SavedType = "POST"
+	SavedTypeComment SavedType = "COMMENT"
+)
+
+// SavedItem is post or comment bookmarked by user, ItemID refers to post or comment depending on Type.
+type SavedItem struct {
+	UserID  uuid.UUID `db:"user_id"`
+	Type    SavedType `db:"-"`
+	ItemID  int       `db:"item_id"`
+	SavedAt time.Time `db:"saved_at"`
+}
+
+type SavedInput struct {
+	Type   SavedType
+	Limit  int32
+	Cursor *string
+}
+
+type SavedEdge struct {
+	Cursor *string
+	Item   *SavedItem
+}
+
+type SavedConnection struct {
+	Edges    []*SavedEdge
+	PageInfo *PageInfo
+}
+
+type SavedPage struct {
+	Items   []*SavedItem
+	HasNext bool
+}
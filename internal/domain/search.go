@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SearchType string
+
+const (
+	SearchTypePost    SearchType = "POST"
+	SearchTypeComment SearchType = "COMMENT"
+)
+
+type SearchSort string
+
+const (
+	SearchSortRelevance SearchSort = "RELEVANCE"
+	SearchSortNew       SearchSort = "NEW"
+	SearchSortRating    SearchSort = "RATING"
+)
+
+// Snippets wrap matched words in highlight marks.
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// Storages wrap matched words of snippets in private use characters instead of highlight marks
+// and strip those characters from content, so that marks are told apart from text looking like them.
+const (
+	SnippetMatchStart = "\uE000"
+	SnippetMatchStop  = "\uE001"
+)
+
+type SearchInput struct {
+	Query  string
+	Type   SearchType
+	Sort   SearchSort
+	Limit  int32
+	Cursor *string
+}
+
+// SearchFilter narrows search results, nil fields do not filter.
+// Comments are filtered by community of their posts.
+type SearchFilter struct {
+	AuthorID  *uuid.UUID
+	Community *string
+	// Content created before given time
+	Before *time.Time
+	// Content created at or after given time
+	After     *time.Time
+	MinRating *int32
+}
+
+// SearchQuery matches posts or comments containing every word of Text.
+// Only cursor of the requested sort order is set.
+type SearchQuery struct {
+	Text         string
+	Filter       SearchFilter
+	Sort         SearchSort
+	Limit        int32
+	RankCursor   *SearchRankCursor
+	TimeCursor   *PostTimeCursor
+	RatingCursor *PostRatingCursor
+}
+
+type SearchRankCursor struct {
+	Rank float64
+	ID   int
+}
+
+// SearchHit is matched post, or comment when Comment is set.
+type SearchHit struct {
+	Post    *Post
+	Comment *Comment
+	Rank    float64
+	// Matched fragment of text with highlighted words
+	Snippet string
+}
+
+type SearchEdge struct {
+	Cursor *string
+	Hit    *SearchHit
+}
+
+type SearchConnection struct {
+	Edges    []*SearchEdge
+	PageInfo *PageInfo
+}
+
+type SearchPage struct {
+	Hits    []*SearchHit
+	HasNext bool
+}
@@ -4,37 +4,286 @@ package errs
 import (
 	"errors"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 )
 
+// Error is exposable error with stable machine-readable code, that clients can rely on instead of message.
+type Error struct {
+	code    string
+	message string
+}
+
+func New(code, message string) *Error {
+	return &Error{code: code, message: message}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Code() string {
+	return e.code
+}
+
+// Extensions are picked up by gqlgen error presenter.
+func (e *Error) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
 var (
-	PostNotFound          = errors.New("post not found")
-	CommentNotFound       = errors.New("comment not found")
-	ParentCommentNotFound = errors.New("parent comment not found")
-	CommentsRestricted    = errors.New("comments disabled for this post")
-	InvalidID             = errors.New("id must be valid integer")
-	CommentDeleted        = errors.New("comment is deleted")
-	ReplyToDeletedComment = errors.New("cannot reply to deleted comment")
-	InvalidCursor         = errors.New("invalid cursor")
-	InternalServer        = errors.New("internal server error")
+	PostNotFound          = New("POST_NOT_FOUND", "post not found")
+	PostDeleted           = New("POST_DELETED", "post is deleted")
+	PostRemoved           = New("POST_REMOVED", "post is removed by moderator")
+	NotDeleted            = New("NOT_DELETED", "content is not deleted")
+	RestoreExpired        = New("RESTORE_PERIOD_EXPIRED", "content was deleted too long ago to be restored")
+	CommentNotFound       = New("COMMENT_NOT_FOUND", "comment not found")
+	ParentCommentNotFound = New("PARENT_COMMENT_NOT_FOUND", "parent comment not found")
+	CommentsRestricted    = New("COMMENTS_RESTRICTED", "comments disabled for this post")
+	InvalidID             = New("INVALID_ID", "id must be valid integer")
+	CommentDeleted        = New("COMMENT_DELETED", "comment is deleted")
+	CommentRemoved        = New("COMMENT_REMOVED", "comment is removed by moderator")
+	NotRemoved            = New("NOT_REMOVED", "content is not removed")
+	ReplyToDeletedComment = New("REPLY_TO_DELETED_COMMENT", "cannot reply to deleted comment")
+	InvalidCursor         = New("INVALID_CURSOR", "invalid cursor")
+	InvalidInput          = New("INVALID_INPUT", "invalid input")
+	RateLimited           = New("RATE_LIMITED", "rate limit exceeded")
+	Unauthenticated       = New("UNAUTHENTICATED", "authentication required")
+	Forbidden             = New("FORBIDDEN", "not enough permissions")
+	ModeratorNotFound     = New("MODERATOR_NOT_FOUND", "user is not moderator of this community")
+	ReportNotFound        = New("REPORT_NOT_FOUND", "report not found")
+	ReportResolved        = New("REPORT_ALREADY_RESOLVED", "report is already resolved")
+	Banned                = New("BANNED", "user is banned")
+	BanNotFound           = New("BAN_NOT_FOUND", "user is not banned")
+	InvalidSearchQuery    = New("INVALID_SEARCH_QUERY", "invalid search query")
+	BlockSelf             = New("CANNOT_BLOCK_SELF", "users cannot block themselves")
+	RevisionNotFound      = New("REVISION_NOT_FOUND", "revision not found")
+	Conflict              = New("CONFLICT", "content was changed since expected version")
+	IdempotencyKeyReused  = New("IDEMPOTENCY_KEY_REUSED", "idempotency key was already used with different input")
+	IdempotencyInProgress = New("IDEMPOTENCY_KEY_IN_PROGRESS", "request with this idempotency key is still in progress")
+	InternalServer        = New("INTERNAL_SERVER_ERROR", "internal server error")
 )
 
-var all = []error{
+var all = []*Error{
 	PostNotFound,
+	PostDeleted,
+	PostRemoved,
+	NotDeleted,
+	RestoreExpired,
 	CommentNotFound,
 	ParentCommentNotFound,
 	CommentsRestricted,
 	InvalidID,
 	CommentDeleted,
+	CommentRemoved,
+	NotRemoved,
 	ReplyToDeletedComment,
 	InvalidCursor,
+	InvalidInput,
+	RateLimited,
+	Unauthenticated,
+	Forbidden,
+	ModeratorNotFound,
+	ReportNotFound,
+	ReportResolved,
+	Banned,
+	BanNotFound,
+	InvalidSearchQuery,
+	BlockSelf,
+	RevisionNotFound,
+	Conflict,
+	IdempotencyKeyReused,
+	IdempotencyInProgress,
 	InternalServer,
 }
 
+// Validation rules reported to clients along with failing field.
+const (
+	RuleRequired = "required"
+	// At least one of fields listed in limit is required
+	RuleAnyRequired = "anyRequired"
+	RuleMaxLength   = "maxLength"
+	RuleMin         = "min"
+	RuleMax         = "max"
+	RuleOneOf       = "oneOf"
+	RuleNotOneOf    = "notOneOf"
+	RuleID          = "id"
+	// Value must match regular expression given in limit
+	RulePattern = "pattern"
+	// Text must contain something besides whitespace and zero-width characters
+	RuleVisible   = "visible"
+	RuleNoControl = "noControlCharacters"
+	// Value must be absolute http or https url
+	RuleURL = "url"
+	// Field must be omitted, e.g. url of text post
+	RuleNotAllowed = "notAllowed"
+)
+
+// FieldError is validation failure of single input field.
+type FieldError struct {
+	// Path of the field in arguments, e.g. "input.title"
+	Field string
+	// Rule is one of Rule* constants
+	Rule string
+	// Limit of the rule, e.g. maximum length; nil if rule has no parameter
+	Limit any
+	Err   error
+}
+
+func Field(field, rule string, limit any, err error) *FieldError {
+	return &FieldError{Field: field, Rule: rule, Limit: limit, Err: err}
+}
+
+// InvalidIDField reports field that is not a valid id.
+func InvalidIDField(field string) *FieldError {
+	return Field(field, RuleID, nil, InvalidID)
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError contains every failed rule of single input, so that client can show them all at once.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	unwrapped := make([]error, len(e.Fields))
+	for i, f := range e.Fields {
+		unwrapped[i] = f
+	}
+	return unwrapped
+}
+
+// InvalidInputError is InvalidInput caused by Err.
+type InvalidInputError struct {
+	Err error
+}
+
 func InvalidInputWrap(err error) error {
-	return fmt.Errorf("Invalid input: %w", err)
+	return &InvalidInputError{Err: err}
+}
+
+func (e *InvalidInputError) Error() string {
+	return fmt.Sprintf("Invalid input: %s", e.Err)
 }
 
+func (e *InvalidInputError) Unwrap() error {
+	return e.Err
+}
+
+func (e *InvalidInputError) Is(target error) bool {
+	return target == InvalidInput
+}
+
+// Extensions are picked up by gqlgen error presenter.
+// Failing fields are listed under "violations" when cause is ValidationError or FieldError.
+func (e *InvalidInputError) Extensions() map[string]any {
+	ext := InvalidInput.Extensions()
+
+	var fields []*FieldError
+	var validationErr *ValidationError
+	var fieldErr *FieldError
+	switch {
+	case errors.As(e.Err, &validationErr):
+		fields = validationErr.Fields
+	case errors.As(e.Err, &fieldErr):
+		fields = []*FieldError{fieldErr}
+	}
+	if len(fields) == 0 {
+		return ext
+	}
+
+	violations := make([]map[string]any, len(fields))
+	for i, f := range fields {
+		violations[i] = map[string]any{
+			"field":   f.Field,
+			"rule":    f.Rule,
+			"message": f.Error(),
+		}
+		if f.Limit != nil {
+			violations[i]["limit"] = f.Limit
+		}
+	}
+	ext["violations"] = violations
+	return ext
+}
+
+// RateLimitedError is RateLimited carrying time after which action is allowed again.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("%s, retry after %ds", RateLimited, e.retryAfterSeconds())
+}
+
+func (e *RateLimitedError) Is(target error) bool {
+	return target == RateLimited
+}
+
+// Extensions are picked up by gqlgen error presenter.
+func (e *RateLimitedError) Extensions() map[string]any {
+	ext := RateLimited.Extensions()
+	ext["retryAfter"] = e.retryAfterSeconds()
+	return ext
+}
+
+func (e *RateLimitedError) retryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// SearchQueryError is InvalidSearchQuery caused by malformed filter of search query.
+type SearchQueryError struct {
+	// Filter as written in query, e.g. "before:yesterday"
+	Filter string
+	Reason string
+}
+
+func (e *SearchQueryError) Error() string {
+	return fmt.Sprintf("invalid search filter %q: %s", e.Filter, e.Reason)
+}
+
+func (e *SearchQueryError) Is(target error) bool {
+	return target == InvalidSearchQuery
+}
+
+// Extensions are picked up by gqlgen error presenter.
+func (e *SearchQueryError) Extensions() map[string]any {
+	ext := InvalidSearchQuery.Extensions()
+	ext["filter"] = e.Filter
+	return ext
+}
+
+// Exposable returns error from err chain that is safe to show to user, nil if there is none.
 func Exposable(err error) error {
+	var rateLimited *RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return rateLimited
+	}
+	var invalidInput *InvalidInputError
+	if errors.As(err, &invalidInput) {
+		return invalidInput
+	}
+	var searchQuery *SearchQueryError
+	if errors.As(err, &searchQuery) {
+		return searchQuery
+	}
+
 	for _, e := range all {
 		if errors.Is(err, e) {
 			return e
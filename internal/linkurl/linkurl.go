@@ -0,0 +1,106 @@
+// Package linkurl parses urls of link posts and normalizes them to detect the same link posted again.
+package linkurl
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+var (
+	InvalidURLErr = errors.New("url must be absolute http or https url")
+	URLHostErr    = errors.New("url must have host name")
+)
+
+// Query parameters only tracking where link was clicked, they do not change linked page
+var trackingParams = map[string]bool{
+	"fbclid": true,
+	"gclid":  true,
+	"yclid":  true,
+	"igshid": true,
+	"ref":    true,
+}
+
+// Link is parsed url of link post.
+type Link struct {
+	// URL is cleaned url to store and show, with lowercase scheme and host
+	URL string
+	// Domain is host name without www prefix, e.g. go.dev
+	Domain string
+	// Normalized is url without scheme, www prefix, default port, fragment, trailing slash
+	// and tracking parameters, with sorted query. Links to the same page normalize to the same string.
+	Normalized string
+}
+
+func Parse(raw string) (*Link, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, InvalidURLErr
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" || u.Opaque != "" {
+		return nil, InvalidURLErr
+	}
+	if u.User != nil {
+		return nil, InvalidURLErr
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" || strings.HasPrefix(host, ".") || strings.Contains(host, "..") {
+		return nil, URLHostErr
+	}
+	port := u.Port()
+	if port == "80" && u.Scheme == "http" || port == "443" && u.Scheme == "https" {
+		port = ""
+	}
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]" // IPv6 literal
+	} else {
+		u.Host = host
+	}
+
+	domain := strings.TrimPrefix(host, "www.")
+	return &Link{
+		URL:        u.String(),
+		Domain:     domain,
+		Normalized: normalize(u, domain, port),
+	}, nil
+}
+
+// NormalizeDomain lowercases domain and drops www prefix the same way as Parse, so that it can be matched with Link.Domain.
+func NormalizeDomain(domain string) string {
+	return strings.TrimPrefix(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), "."), "www.")
+}
+
+func normalize(u *url.URL, domain, port string) string {
+	var b strings.Builder
+	b.WriteString(domain)
+	if port != "" {
+		b.WriteString(":" + port)
+	}
+	b.WriteString(strings.TrimRight(u.EscapedPath(), "/"))
+
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		if !trackingParams[strings.ToLower(k)] && !strings.HasPrefix(strings.ToLower(k), "utm_") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	sep := "?"
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			b.WriteString(sep + url.QueryEscape(k) + "=" + url.QueryEscape(v))
+			sep = "&"
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,205 @@
+// Package loader batches lookups made per node while resolving single GraphQL request,
+// so that a page of N items costs a constant number of storage queries instead of N.
+package loader
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/comment"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/post"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/preview"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/saved"
+)
+
+// wait is how long loaders collect keys before fetching them in one batch.
+const wait = 2 * time.Millisecond
+
+type loadersKey struct{}
+
+// Loaders are request-scoped: values they cache must not outlive request.
+type Loaders struct {
+	Post         *dataloader.Loader[int, *domain.Post]
+	Comment      *dataloader.Loader[int, *domain.Comment]
+	PostVote     *dataloader.Loader[int, int8]
+	CommentVote  *dataloader.Loader[int, int8]
+	PostSaved    *dataloader.Loader[int, bool]
+	CommentSaved *dataloader.Loader[int, bool]
+	// PostPreview resolves posts without preview to nil
+	PostPreview *dataloader.Loader[int, *domain.LinkPreview]
+	// Comments loads first pages of comments, requests with cursor should go to comment service directly
+	Comments *dataloader.Loader[CommentsKey, *domain.CommentConnection]
+}
+
+// CommentsKey identifies first page of sibling comments.
+type CommentsKey struct {
+	PostID int
+	// 0 for top-level comments of post
+	ParentID int
+	Sort     domain.SortOrder
+	Limit    int32
+}
+
+func New(posts *post.Service, comments *comment.Service, savedItems *saved.Service, previews *preview.Service) *Loaders {
+	return &Loaders{
+		Post: dataloader.NewBatchedLoader(
+			byID(posts.GetPostsByIDs, func(p *domain.Post) int { return p.ID }, errs.PostNotFound),
+			dataloader.WithWait[int, *domain.Post](wait),
+		),
+		Comment: dataloader.NewBatchedLoader(
+			byID(comments.GetCommentsByIDs, func(c *domain.Comment) int { return c.ID }, errs.CommentNotFound),
+			dataloader.WithWait[int, *domain.Comment](wait),
+		),
+		PostVote: dataloader.NewBatchedLoader(
+			votes(posts.GetMyVotes),
+			dataloader.WithWait[int, int8](wait),
+		),
+		CommentVote: dataloader.NewBatchedLoader(
+			votes(comments.GetMyVotes),
+			dataloader.WithWait[int, int8](wait),
+		),
+		PostSaved: dataloader.NewBatchedLoader(
+			savedFlags(savedItems, domain.SavedTypePost),
+			dataloader.WithWait[int, bool](wait),
+		),
+		CommentSaved: dataloader.NewBatchedLoader(
+			savedFlags(savedItems, domain.SavedTypeComment),
+			dataloader.WithWait[int, bool](wait),
+		),
+		PostPreview: dataloader.NewBatchedLoader(
+			linkPreviews(previews),
+			dataloader.WithWait[int, *domain.LinkPreview](wait),
+		),
+		Comments: dataloader.NewBatchedLoader(
+			firstPages(comments),
+			dataloader.WithWait[CommentsKey, *domain.CommentConnection](wait),
+		),
+	}
+}
+
+// Middleware attaches fresh Loaders to every request.
+func Middleware(posts *post.Service, comments *comment.Service, savedItems *saved.Service, previews *preview.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), loadersKey{}, New(posts, comments, savedItems, previews))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// For returns Loaders of current request, ok is false when ctx did not pass through Middleware.
+func For(ctx context.Context) (loaders *Loaders, ok bool) {
+	loaders, ok = ctx.Value(loadersKey{}).(*Loaders)
+	return loaders, ok
+}
+
+// byID adapts fetch of entities by ids to batch function, missing entities resolve to notFound error.
+func byID[V any](fetch func(context.Context, []int) ([]V, error), idOf func(V) int, notFound error) dataloader.BatchFunc[int, V] {
+	return func(ctx context.Context, ids []int) []*dataloader.Result[V] {
+		results := make([]*dataloader.Result[V], len(ids))
+
+		values, err := fetch(ctx, ids)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[V]{Error: err}
+			}
+			return results
+		}
+
+		byID := make(map[int]V, len(values))
+		for _, v := range values {
+			byID[idOf(v)] = v
+		}
+		for i, id := range ids {
+			v, ok := byID[id]
+			if !ok {
+				results[i] = &dataloader.Result[V]{Error: notFound}
+				continue
+			}
+			results[i] = &dataloader.Result[V]{Data: v}
+		}
+		return results
+	}
+}
+
+// votes adapts fetch of current user votes to batch function, absent vote resolves to 0.
+func votes(fetch func(context.Context, []int) (map[int]int8, error)) dataloader.BatchFunc[int, int8] {
+	return func(ctx context.Context, ids []int) []*dataloader.Result[int8] {
+		results := make([]*dataloader.Result[int8], len(ids))
+
+		votes, err := fetch(ctx, ids)
+		for i, id := range ids {
+			results[i] = &dataloader.Result[int8]{Data: votes[id], Error: err}
+		}
+		return results
+	}
+}
+
+// savedFlags adapts fetch of items of given type saved by current user to batch function, absent item resolves to false.
+func savedFlags(savedItems *saved.Service, itemType domain.SavedType) dataloader.BatchFunc[int, bool] {
+	return func(ctx context.Context, ids []int) []*dataloader.Result[bool] {
+		results := make([]*dataloader.Result[bool], len(ids))
+
+		flags, err := savedItems.GetMySaved(ctx, itemType, ids)
+		for i, id := range ids {
+			results[i] = &dataloader.Result[bool]{Data: flags[id], Error: err}
+		}
+		return results
+	}
+}
+
+func linkPreviews(previews *preview.Service) dataloader.BatchFunc[int, *domain.LinkPreview] {
+	return func(ctx context.Context, ids []int) []*dataloader.Result[*domain.LinkPreview] {
+		results := make([]*dataloader.Result[*domain.LinkPreview], len(ids))
+
+		found, err := previews.GetPreviews(ctx, ids)
+		for i, id := range ids {
+			results[i] = &dataloader.Result[*domain.LinkPreview]{Data: found[id], Error: err}
+		}
+		return results
+	}
+}
+
+// firstPages groups keys by sort and limit and fetches every group with single comment service call.
+func firstPages(comments *comment.Service) dataloader.BatchFunc[CommentsKey, *domain.CommentConnection] {
+	type group struct {
+		sort  domain.SortOrder
+		limit int32
+	}
+
+	return func(ctx context.Context, keys []CommentsKey) []*dataloader.Result[*domain.CommentConnection] {
+		results := make([]*dataloader.Result[*domain.CommentConnection], len(keys))
+
+		groups := make(map[group][]int)
+		for i, k := range keys {
+			g := group{sort: k.Sort, limit: k.Limit}
+			groups[g] = append(groups[g], i)
+		}
+
+		for g, indexes := range groups {
+			parents := make([]domain.CommentsParent, len(indexes))
+			for j, i := range indexes {
+				parents[j] = domain.CommentsParent{PostID: keys[i].PostID}
+				if keys[i].ParentID != 0 {
+					parentID := keys[i].ParentID
+					parents[j].ParentID = &parentID
+				}
+			}
+
+			connections, err := comments.GetCommentsFirstPages(ctx, parents, g.sort, g.limit)
+			for j, i := range indexes {
+				if err != nil {
+					results[i] = &dataloader.Result[*domain.CommentConnection]{Error: err}
+					continue
+				}
+				results[i] = &dataloader.Result[*domain.CommentConnection]{Data: connections[j]}
+			}
+		}
+		return results
+	}
+}
@@ -0,0 +1,27 @@
+package loader
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestForWithoutMiddleware(t *testing.T) {
+	loaders, ok := For(context.Background())
+	if ok || loaders != nil {
+		t.Fatalf("For() = %v, %v, want nil, false", loaders, ok)
+	}
+}
+
+func TestForWithMiddleware(t *testing.T) {
+	var ok bool
+	handler := Middleware(nil, nil, nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok = For(r.Context())
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/query", nil))
+
+	if !ok {
+		t.Fatal("For() found no loaders in request passed through Middleware")
+	}
+}
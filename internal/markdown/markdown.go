@@ -0,0 +1,183 @@
+// Package markdown renders subset of CommonMark used in posts and comments to HTML safe to embed in pages:
+// paragraphs, emphasis, links, code, quotes, lists and >!spoilers!<.
+// Raw HTML is escaped, images are rendered as links and links may only point to web pages or mail addresses.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Rel of rendered links, search engines should not credit links of users
+const linkRel = "nofollow ugc noopener"
+
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// Kind is kind of rendered content, content of different kinds may have the same id.
+type Kind string
+
+const (
+	KindPost    Kind = "post"
+	KindComment Kind = "comment"
+)
+
+// Renderer caches HTML of content by its revision, as content of revision never changes.
+type Renderer struct {
+	md    goldmark.Markdown
+	cache *lru.Cache[cacheKey, string]
+}
+
+type cacheKey struct {
+	kind    Kind
+	id      string
+	version int32
+}
+
+// New creates Renderer keeping HTML of up to cacheSize revisions.
+func New(cacheSize int) (*Renderer, error) {
+	cache, err := lru.New[cacheKey, string](cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create markdown cache: %w", err)
+	}
+
+	// Headings, thematic breaks and HTML blocks are left out, such lines are rendered as paragraphs
+	md := goldmark.New(
+		goldmark.WithParser(parser.NewParser(
+			parser.WithBlockParsers(
+				util.Prioritized(parser.NewListParser(), 300),
+				util.Prioritized(parser.NewListItemParser(), 400),
+				util.Prioritized(parser.NewCodeBlockParser(), 500),
+				util.Prioritized(parser.NewFencedCodeBlockParser(), 700),
+				util.Prioritized(spoilerBlockquoteParser{parser.NewBlockquoteParser()}, 800),
+				util.Prioritized(parser.NewParagraphParser(), 1000),
+			),
+			parser.WithInlineParsers(
+				util.Prioritized(parser.NewCodeSpanParser(), 100),
+				util.Prioritized(parser.NewLinkParser(), 200),
+				util.Prioritized(parser.NewAutoLinkParser(), 300),
+				util.Prioritized(parser.NewEmphasisParser(), 500),
+				util.Prioritized(spoilerParser{}, 600),
+			),
+			parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+			parser.WithASTTransformers(
+				util.Prioritized(spoilerTransformer{}, 100),
+				util.Prioritized(linkTransformer{}, 200),
+			),
+		)),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(spoilerRenderer{}, 500)),
+		),
+	)
+	return &Renderer{md: md, cache: cache}, nil
+}
+
+// Render returns HTML of content, kind and id identify content and version its revision.
+func (r *Renderer) Render(kind Kind, id string, version int32, source string) (string, error) {
+	key := cacheKey{kind: kind, id: id, version: version}
+	if html, ok := r.cache.Get(key); ok {
+		return html, nil
+	}
+
+	html, err := r.RenderUncached(source)
+	if err != nil {
+		return "", err
+	}
+	r.cache.Add(key, html)
+	return html, nil
+}
+
+// RenderUncached renders text that is not revision of content, e.g. placeholder of deleted content.
+func (r *Renderer) RenderUncached(source string) (string, error) {
+	var b bytes.Buffer
+	if err := r.md.Convert([]byte(source), &b); err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+	return b.String(), nil
+}
+
+// linkTransformer turns images into links and unwraps links with disallowed destinations.
+type linkTransformer struct{}
+
+func (t linkTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	var links []ast.Node
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.Kind() {
+		case ast.KindLink, ast.KindImage, ast.KindAutoLink:
+			links = append(links, n)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	source := reader.Source()
+	for _, n := range links {
+		switch n := n.(type) {
+		case *ast.Image:
+			link := ast.NewLink()
+			link.Destination, link.Title = n.Destination, n.Title
+			moveChildren(link, n)
+			n.Parent().ReplaceChild(n.Parent(), n, link)
+			t.check(link)
+		case *ast.Link:
+			t.check(n)
+		case *ast.AutoLink:
+			if n.AutoLinkType == ast.AutoLinkEmail {
+				n.SetAttributeString("rel", []byte(linkRel))
+				continue
+			}
+			if !allowedURL(n.URL(source)) {
+				n.Parent().ReplaceChild(n.Parent(), n, ast.NewString(n.Label(source)))
+				continue
+			}
+			n.SetAttributeString("rel", []byte(linkRel))
+		}
+	}
+}
+
+// check unwraps link with disallowed destination, so that only its text is left.
+func (t linkTransformer) check(link *ast.Link) {
+	if allowedURL(link.Destination) {
+		link.SetAttributeString("rel", []byte(linkRel))
+		return
+	}
+
+	parent := link.Parent()
+	for c := link.FirstChild(); c != nil; {
+		next := c.NextSibling()
+		parent.InsertBefore(parent, link, c)
+		c = next
+	}
+	parent.RemoveChild(parent, link)
+}
+
+func moveChildren(dst, src ast.Node) {
+	for c := src.FirstChild(); c != nil; {
+		next := c.NextSibling()
+		dst.AppendChild(dst, c)
+		c = next
+	}
+}
+
+// allowedURL accepts relative urls and absolute ones with allowed scheme.
+// Renderer resolves escapes and entity references of destination, e.g. "javascript&colon;",
+// so destination is checked in the same form as it ends up in page.
+func allowedURL(destination []byte) bool {
+	resolved := util.ResolveEntityNames(util.ResolveNumericReferences(util.UnescapePunctuations(destination)))
+	u, err := url.Parse(strings.TrimSpace(string(resolved)))
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || allowedSchemes[strings.ToLower(u.Scheme)]
+}
@@ -0,0 +1,68 @@
+package markdown
+
+import "testing"
+
+func TestRenderSanitizes(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"raw HTML block", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+		{"raw inline HTML", "hi <b onclick=x>bold</b>", "<p>hi &lt;b onclick=x&gt;bold&lt;/b&gt;</p>\n"},
+		{"javascript link", "[x](javascript:alert(1))", "<p>x</p>\n"},
+		{"uppercase scheme", "[x](JavaScript:alert(1))", "<p>x</p>\n"},
+		{"named entity in scheme", "[x](javascript&colon;alert(1))", "<p>x</p>\n"},
+		{"numeric entity in scheme", "[x](&#106;avascript:alert(1))", "<p>x</p>\n"},
+		{"tab entity in scheme", "[x](java&Tab;script:alert(1))", "<p>x</p>\n"},
+		{"escaped punctuation in scheme", `[x](javascript\:alert(1))`, "<p>x</p>\n"},
+		{"data link", "[x](data:text/html;base64,PHNjcmlwdD4=)", "<p>x</p>\n"},
+		{
+			"web link",
+			`[x](https://example.com/?a=1&b=2 "t")`,
+			`<p><a href="https://example.com/?a=1&amp;b=2" title="t" rel="nofollow ugc noopener">x</a></p>` + "\n",
+		},
+		{"relative link", "[x](/relative)", `<p><a href="/relative" rel="nofollow ugc noopener">x</a></p>` + "\n"},
+		{
+			"image becomes link",
+			"![alt](https://example.com/a.png)",
+			`<p><a href="https://example.com/a.png" rel="nofollow ugc noopener">alt</a></p>` + "\n",
+		},
+		{"javascript image", "![alt](javascript:alert(1))", "<p>alt</p>\n"},
+		{"javascript reference link", "[x][ref]\n\n[ref]: javascript:alert(1)", "<p>x</p>\n"},
+		{
+			"web reference link",
+			"[x][ref]\n\n[ref]: https://example.com",
+			`<p><a href="https://example.com" rel="nofollow ugc noopener">x</a></p>` + "\n",
+		},
+		{"javascript autolink", "<javascript:alert(1)>", "<p>javascript:alert(1)</p>\n"},
+		{
+			"mail autolink",
+			"<me@example.com>",
+			`<p><a href="mailto:me@example.com" rel="nofollow ugc noopener">me@example.com</a></p>` + "\n",
+		},
+		{"spoiler", "a >!secret!< b", `<p>a <span class="spoiler">secret</span> b</p>` + "\n"},
+		{"spoiler at line start is not quote", ">!secret!<", `<p><span class="spoiler">secret</span></p>` + "\n"},
+		{"unpaired spoiler mark", ">!open", "<p>&gt;!open</p>\n"},
+		{
+			"javascript link in spoiler",
+			">!see [x](javascript:alert(1))!<",
+			`<p><span class="spoiler">see x</span></p>` + "\n",
+		},
+	}
+	r, err := New(10)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.RenderUncached(tt.source)
+			if err != nil {
+				t.Fatalf("RenderUncached(%q) error = %v", tt.source, err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderUncached(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
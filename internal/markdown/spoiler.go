@@ -0,0 +1,159 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// spoiler is inline text hidden until reader reveals it, written as >!text!<.
+type spoiler struct {
+	ast.BaseInline
+}
+
+var kindSpoiler = ast.NewNodeKind("Spoiler")
+
+func (n *spoiler) Kind() ast.NodeKind {
+	return kindSpoiler
+}
+
+func (n *spoiler) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// spoilerMark is opening or closing mark of spoiler, spoilerTransformer replaces marks
+// with spoilers around nodes between them, or with plain text if they are unpaired.
+type spoilerMark struct {
+	ast.BaseInline
+	opening bool
+	segment text.Segment
+}
+
+var kindSpoilerMark = ast.NewNodeKind("SpoilerMark")
+
+func (n *spoilerMark) Kind() ast.NodeKind {
+	return kindSpoilerMark
+}
+
+func (n *spoilerMark) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+var (
+	spoilerOpen  = []byte(">!")
+	spoilerClose = []byte("!<")
+)
+
+// spoilerParser parses marks, '!' is also trigger of images, so closing mark is tried after link parser.
+type spoilerParser struct{}
+
+func (p spoilerParser) Trigger() []byte {
+	return []byte{'>', '!'}
+}
+
+func (p spoilerParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, segment := block.PeekLine()
+	var opening bool
+	switch {
+	case bytes.HasPrefix(line, spoilerOpen):
+		opening = true
+	case bytes.HasPrefix(line, spoilerClose):
+		opening = false
+	default:
+		return nil
+	}
+	block.Advance(2)
+	return &spoilerMark{opening: opening, segment: segment.WithStop(segment.Start + 2)}
+}
+
+// spoilerTransformer pairs marks among siblings, marks cannot span across blocks or links.
+type spoilerTransformer struct{}
+
+func (t spoilerTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	var parents []ast.Node
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			if hasMark(n) {
+				parents = append(parents, n)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, parent := range parents {
+		pairMarks(parent)
+	}
+}
+
+func hasMark(n ast.Node) bool {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if _, ok := c.(*spoilerMark); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func pairMarks(parent ast.Node) {
+	var open *spoilerMark
+	for c := parent.FirstChild(); c != nil; {
+		next := c.NextSibling()
+		mark, ok := c.(*spoilerMark)
+		switch {
+		case !ok:
+		case mark.opening:
+			if open != nil {
+				parent.ReplaceChild(parent, open, ast.NewTextSegment(open.segment))
+			}
+			open = mark
+		case open != nil:
+			s := &spoiler{}
+			for n := open.NextSibling(); n != mark; {
+				following := n.NextSibling()
+				s.AppendChild(s, n)
+				n = following
+			}
+			parent.ReplaceChild(parent, open, s)
+			parent.RemoveChild(parent, mark)
+			open = nil
+		default:
+			parent.ReplaceChild(parent, mark, ast.NewTextSegment(mark.segment))
+		}
+		c = next
+	}
+	if open != nil {
+		parent.ReplaceChild(parent, open, ast.NewTextSegment(open.segment))
+	}
+}
+
+// spoilerBlockquoteParser keeps lines starting with spoiler from being parsed as quotes.
+type spoilerBlockquoteParser struct {
+	parser.BlockParser
+}
+
+func (p spoilerBlockquoteParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.PeekLine()
+	if bytes.HasPrefix(bytes.TrimLeft(line, " "), spoilerOpen) {
+		return nil, parser.NoChildren
+	}
+	return p.BlockParser.Open(parent, reader, pc)
+}
+
+type spoilerRenderer struct{}
+
+func (r spoilerRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindSpoiler, r.renderSpoiler)
+}
+
+func (r spoilerRenderer) renderSpoiler(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString(`<span class="spoiler">`)
+	} else {
+		_, _ = w.WriteString(`</span>`)
+	}
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,20 @@
+// Package ranking contains scoring functions used to order content
+package ranking
+
+import "math"
+
+// z is the quantile of the standard normal distribution for 95% confidence.
+const z = 1.959963984540054
+
+// Wilson returns the lower bound of the Wilson score confidence interval
+// for the share of upvotes. Content without votes scores 0, rounding error never makes score negative.
+func Wilson(upvotes, downvotes int32) float64 {
+	n := float64(upvotes) + float64(downvotes)
+	if n == 0 {
+		return 0
+	}
+
+	p := float64(upvotes) / n
+	z2 := z * z
+	return max(0, (p+z2/(2*n)-z*math.Sqrt((p*(1-p)+z2/(4*n))/n))/(1+z2/n))
+}
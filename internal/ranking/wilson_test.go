@@ -0,0 +1,50 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+)
+
+// Migration 002_comments_best_sort backfills best_score with the same formula in SQL,
+// values below must stay in sync with it.
+func TestWilson(t *testing.T) {
+	tests := []struct {
+		name               string
+		upvotes, downvotes int32
+		want               float64
+	}{
+		{"no votes", 0, 0, 0},
+		{"single upvote", 1, 0, 0.20654931437723742},
+		{"single downvote", 0, 1, 0},
+		{"all up", 100, 0, 0.9630065017930143},
+		{"all down", 0, 100, 0},
+		{"mostly up", 10, 2, 0.5519691377470266},
+		{"even", 50, 50, 0.4038315303659957},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Wilson(tt.upvotes, tt.downvotes)
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Wilson(%d, %d) = %v, want %v", tt.upvotes, tt.downvotes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWilsonOrdersByConfidence(t *testing.T) {
+	// The same share of upvotes is more trustworthy with more votes
+	if Wilson(10, 0) >= Wilson(100, 0) {
+		t.Error("Wilson(10, 0) should be lower than Wilson(100, 0)")
+	}
+	// More downvotes always lower the score
+	if Wilson(10, 5) >= Wilson(10, 1) {
+		t.Error("Wilson(10, 5) should be lower than Wilson(10, 1)")
+	}
+	for up := int32(0); up <= 50; up++ {
+		for down := int32(0); down <= 50; down++ {
+			if s := Wilson(up, down); s < 0 || s > 1 {
+				t.Fatalf("Wilson(%d, %d) = %v, want value in [0, 1]", up, down, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Package searchquery parses filters written along with words of search query, e.g.
+// `generics author:<uuid> community:golang after:2024-01-01 before:2024-07-01 minRating:10`.
+package searchquery
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+var (
+	InvalidAuthorErr    = errors.New("author must be user id")
+	InvalidDateErr      = errors.New("date must be YYYY-MM-DD or RFC 3339 timestamp")
+	InvalidMinRatingErr = errors.New("minimum rating must be integer")
+)
+
+const (
+	filterAuthor    = "author"
+	filterCommunity = "community"
+	filterBefore    = "before"
+	filterAfter     = "after"
+	filterMinRating = "minrating"
+)
+
+// Parse splits query into words and filters. Filter is a word of form name:value with known
+// case-insensitive name outside of quotes, other words are left as is.
+// Dates are either YYYY-MM-DD, meaning start of day in UTC, or RFC 3339 timestamps.
+func Parse(query string) (string, domain.SearchFilter, error) {
+	var filter domain.SearchFilter
+	var words []string
+	seen := make(map[string]string) // Filter name -> token
+
+	for _, token := range split(query) {
+		name, value, ok := strings.Cut(token, ":")
+		name = strings.ToLower(name)
+		if !ok || strings.HasPrefix(token, `"`) || !known(name) {
+			words = append(words, token)
+			continue
+		}
+
+		if _, ok := seen[name]; ok {
+			return "", filter, &errs.SearchQueryError{Filter: token, Reason: "filter is repeated"}
+		}
+		seen[name] = token
+		if value == "" {
+			return "", filter, &errs.SearchQueryError{Filter: token, Reason: "value is missing"}
+		}
+
+		if err := apply(&filter, name, value); err != nil {
+			return "", filter, &errs.SearchQueryError{Filter: token, Reason: err.Error()}
+		}
+	}
+
+	if filter.Before != nil && filter.After != nil && !filter.After.Before(*filter.Before) {
+		return "", filter, &errs.SearchQueryError{Filter: seen[filterAfter], Reason: "date must be earlier than date of before filter"}
+	}
+	if len(words) == 0 {
+		return "", filter, &errs.SearchQueryError{Filter: query, Reason: "query needs at least one word besides filters"}
+	}
+	return strings.Join(words, " "), filter, nil
+}
+
+func known(name string) bool {
+	switch name {
+	case filterAuthor, filterCommunity, filterBefore, filterAfter, filterMinRating:
+		return true
+	}
+	return false
+}
+
+func apply(filter *domain.SearchFilter, name, value string) error {
+	switch name {
+	case filterAuthor:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return InvalidAuthorErr
+		}
+		filter.AuthorID = &id
+
+	case filterCommunity:
+		community := strings.ToLower(value)
+		filter.Community = &community
+
+	case filterBefore, filterAfter:
+		t, err := parseDate(value)
+		if err != nil {
+			return InvalidDateErr
+		}
+		if name == filterBefore {
+			filter.Before = &t
+		} else {
+			filter.After = &t
+		}
+
+	case filterMinRating:
+		rating, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return InvalidMinRatingErr
+		}
+		minRating := int32(rating)
+		filter.MinRating = &minRating
+	}
+	return nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// split breaks query on whitespace outside of double quotes, quotes are kept in words.
+func split(query string) []string {
+	var tokens []string
+	var b strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			b.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if b.Len() > 0 {
+				tokens = append(tokens, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		tokens = append(tokens, b.String())
+	}
+	return tokens
+}
@@ -0,0 +1,114 @@
+package searchquery
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+func TestParse(t *testing.T) {
+	author := uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	tests := []struct {
+		name       string
+		query      string
+		wantText   string
+		wantFilter domain.SearchFilter
+	}{
+		{"words only", "go  generics\ttutorial", "go generics tutorial", domain.SearchFilter{}},
+		{
+			"every filter",
+			"generics author:6ba7b810-9dad-11d1-80b4-00c04fd430c8 community:GoLang after:2024-01-01 before:2024-07-01 minRating:10",
+			"generics",
+			domain.SearchFilter{
+				AuthorID:  &author,
+				Community: ptr("golang"),
+				Before:    ptr(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)),
+				After:     ptr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				MinRating: ptr(int32(10)),
+			},
+		},
+		{"case-insensitive filter name", "go COMMUNITY:golang", "go", domain.SearchFilter{Community: ptr("golang")}},
+		{"quoted filter is word", `"community:golang" go`, `"community:golang" go`, domain.SearchFilter{}},
+		{"quoted phrase keeps spaces", `"hello   world" community:golang`, `"hello   world"`, domain.SearchFilter{Community: ptr("golang")}},
+		{"unknown filter is word", "go lang:en", "go lang:en", domain.SearchFilter{}},
+		{
+			"RFC 3339 timestamp",
+			"go after:2024-01-01T10:00:00+03:00",
+			"go",
+			domain.SearchFilter{After: ptr(time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC))},
+		},
+		{"negative min rating", "go minRating:-5", "go", domain.SearchFilter{MinRating: ptr(int32(-5))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, filter, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.query, err)
+			}
+			if text != tt.wantText {
+				t.Errorf("Parse(%q) text = %q, want %q", tt.query, text, tt.wantText)
+			}
+			if !equalFilters(filter, tt.wantFilter) {
+				t.Errorf("Parse(%q) filter = %+v, want %+v", tt.query, filter, tt.wantFilter)
+			}
+		})
+	}
+}
+
+func TestParseRejectsMalformedFilters(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantFilter string
+	}{
+		{"repeated filter", "go community:golang Community:rust", "Community:rust"},
+		{"empty value", "go community:", "community:"},
+		{"invalid author", "go author:alice", "author:alice"},
+		{"invalid date", "go before:yesterday", "before:yesterday"},
+		{"date without zone", "go before:2024-01-01T10:00:00", "before:2024-01-01T10:00:00"},
+		{"after equal to before", "go after:2024-01-01 before:2024-01-01", "after:2024-01-01"},
+		{"after later than before", "go before:2024-01-01 after:2024-02-01", "after:2024-02-01"},
+		{"fractional min rating", "go minRating:1.5", "minRating:1.5"},
+		{"min rating overflowing int32", "go minRating:2147483648", "minRating:2147483648"},
+		{"filters only", "community:golang minRating:5", "community:golang minRating:5"},
+		{"empty query", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Parse(tt.query)
+			if !errors.Is(err, errs.InvalidSearchQuery) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.query, err, errs.InvalidSearchQuery)
+			}
+			var queryErr *errs.SearchQueryError
+			if !errors.As(err, &queryErr) || queryErr.Filter != tt.wantFilter {
+				t.Errorf("Parse(%q) error = %v, want error of filter %q", tt.query, err, tt.wantFilter)
+			}
+		})
+	}
+}
+
+// equalFilters compares times with time.Equal, so that location of parsed timestamp does not matter.
+func equalFilters(a, b domain.SearchFilter) bool {
+	if !equalTimes(a.Before, b.Before) || !equalTimes(a.After, b.After) {
+		return false
+	}
+	a.Before, a.After, b.Before, b.After = nil, nil, nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
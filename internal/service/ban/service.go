@@ -0,0 +1,114 @@
+package ban
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/role"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+)
+
+type Service struct {
+	storage storage.Storage
+	roles   *role.Service
+}
+
+func NewService(storage storage.Storage, roles *role.Service) *Service {
+	return &Service{storage: storage, roles: roles}
+}
+
+// CheckBanned returns errs.Banned if user is banned in community or globally.
+func (s *Service) CheckBanned(ctx context.Context, community string, userID uuid.UUID) error {
+	_, err := s.storage.GetActiveBan(ctx, community, userID)
+	if errors.Is(err, errs.BanNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("storage failed to get active ban: %w", err)
+	}
+	return errs.Banned
+}
+
+// BanUser bans user on behalf of admin, or of moderator when ban is limited to community.
+// Only users of lower role than the current user in scope of ban can be banned.
+// Banning already banned user replaces existing ban.
+func (s *Service) BanUser(ctx context.Context, input *domain.BanInput) (*domain.Ban, error) {
+	if err := s.authorize(ctx, input.Community); err != nil {
+		return nil, err
+	}
+	input.BannedBy, _ = auth.UserID(ctx) // authorized user is always authenticated
+
+	actorRole, err := s.roles.RoleOf(ctx, input.Community, input.BannedBy)
+	if err != nil {
+		return nil, err
+	}
+	targetRole, err := s.roles.RoleOf(ctx, input.Community, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !actorRole.Outranks(targetRole) {
+		return nil, errs.Forbidden
+	}
+
+	ban, err := s.storage.BanUser(ctx, input, modLogEntry(ctx, domain.ModLogActionBanUser, input.Community, input.UserID))
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to ban user: %w", err)
+	}
+
+	slog.Info("user banned", "userID", ban.UserID, "community", ban.Community, "expiresAt", ban.ExpiresAt, "bannedBy", ban.BannedBy)
+	return ban, nil
+}
+
+func (s *Service) UnbanUser(ctx context.Context, community *string, userID uuid.UUID) error {
+	if err := s.authorize(ctx, community); err != nil {
+		return err
+	}
+
+	_, err := s.storage.UnbanUser(ctx, community, userID, modLogEntry(ctx, domain.ModLogActionUnbanUser, community, userID))
+	if err != nil {
+		return fmt.Errorf("storage failed to unban user: %w", err)
+	}
+
+	slog.Info("user unbanned", "userID", userID, "community", community)
+	return nil
+}
+
+// GetActiveBans lists active bans of community, or global ones when community is nil.
+func (s *Service) GetActiveBans(ctx context.Context, community *string) ([]*domain.Ban, error) {
+	if err := s.authorize(ctx, community); err != nil {
+		return nil, err
+	}
+
+	bans, err := s.storage.GetActiveBans(ctx, community)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get active bans: %w", err)
+	}
+	return bans, nil
+}
+
+// authorize allows global bans to admins and community bans to moderators of community.
+func (s *Service) authorize(ctx context.Context, community *string) error {
+	if community == nil {
+		return s.roles.AuthorizeAdmin(ctx)
+	}
+	return s.roles.AuthorizeModerator(ctx, *community)
+}
+
+// modLogEntry describes change of ban for audit log, global bans go to global log.
+func modLogEntry(ctx context.Context, action domain.ModLogAction, community *string, userID uuid.UUID) *domain.AddModLogEntryInput {
+	actorID, _ := auth.UserID(ctx) // authorized user is always authenticated
+	return &domain.AddModLogEntryInput{
+		Community:  community,
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: domain.ModLogTargetUser,
+		TargetID:   userID.String(),
+	}
+}
@@ -0,0 +1,96 @@
+package ban
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/role"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage/inmemory"
+)
+
+var (
+	admin      = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	otherAdmin = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	moderator  = uuid.MustParse("00000000-0000-0000-0000-000000000003")
+	otherMod   = uuid.MustParse("00000000-0000-0000-0000-000000000004")
+	user       = uuid.MustParse("00000000-0000-0000-0000-000000000005")
+)
+
+const community = "golang"
+
+// newTestService returns ban service over in-memory storage where moderator and otherMod moderate community.
+func newTestService(t *testing.T) (*Service, *inmemory.Storage) {
+	t.Helper()
+	storage := inmemory.New()
+	roles := role.NewService(storage, []uuid.UUID{admin, otherAdmin})
+	adminCtx := auth.WithUserID(context.Background(), admin)
+	for _, id := range []uuid.UUID{moderator, otherMod} {
+		if _, err := roles.AddModerator(adminCtx, community, id); err != nil {
+			t.Fatalf("failed to add moderator: %v", err)
+		}
+	}
+	return NewService(storage, roles), storage
+}
+
+func TestBanUserChecksRoleOfTarget(t *testing.T) {
+	c := community
+	tests := []struct {
+		name      string
+		actor     uuid.UUID
+		community *string
+		target    uuid.UUID
+		want      error
+	}{
+		{"moderator bans user", moderator, &c, user, nil},
+		{"moderator bans self", moderator, &c, moderator, errs.Forbidden},
+		{"moderator bans other moderator", moderator, &c, otherMod, errs.Forbidden},
+		{"moderator bans admin", moderator, &c, admin, errs.Forbidden},
+		{"admin bans moderator in community", admin, &c, moderator, nil},
+		{"admin bans moderator globally", admin, nil, moderator, nil},
+		{"admin bans self", admin, nil, admin, errs.Forbidden},
+		{"admin bans other admin", admin, &c, otherAdmin, errs.Forbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestService(t)
+			ctx := auth.WithUserID(context.Background(), tt.actor)
+
+			_, err := s.BanUser(ctx, &domain.BanInput{Community: tt.community, UserID: tt.target})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("BanUser() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobalBansAreLogged(t *testing.T) {
+	s, storage := newTestService(t)
+	ctx := auth.WithUserID(context.Background(), admin)
+
+	if _, err := s.BanUser(ctx, &domain.BanInput{UserID: user}); err != nil {
+		t.Fatalf("failed to ban user: %v", err)
+	}
+	if err := s.UnbanUser(ctx, nil, user); err != nil {
+		t.Fatalf("failed to unban user: %v", err)
+	}
+
+	page, err := storage.GetModLog(context.Background(), &domain.ModLogFilter{}, 10, nil)
+	if err != nil {
+		t.Fatalf("failed to get mod log: %v", err)
+	}
+	want := []domain.ModLogAction{domain.ModLogActionUnbanUser, domain.ModLogActionBanUser}
+	if len(page.Entries) != len(want) {
+		t.Fatalf("got %d global mod log entries, want %d", len(page.Entries), len(want))
+	}
+	for i, e := range page.Entries {
+		if e.Action != want[i] || e.Community != nil || e.TargetID != user.String() {
+			t.Errorf("entry %d = %s of %s in %v, want global %s of %s", i, e.Action, e.TargetID, e.Community, want[i], user)
+		}
+	}
+}
@@ -3,13 +3,28 @@ package comment
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"strconv"
 
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/cursorcoder"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/ban"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/idempotency"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/role"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/textdiff"
 )
 
 type Service struct {
 	storage storage.Storage
+	roles   *role.Service
+	bans    *ban.Service
+	// Deduplicates retried creations
+	idempotency *idempotency.Service
 }
 
 func (s *Service) GetComment(ctx context.Context, domainID int) (*domain.Comment, error) {
@@ -21,15 +36,41 @@ func (s *Service) GetComment(ctx context.Context, domainID int) (*domain.Comment
 }
 
 func (s *Service) VoteComment(ctx context.Context, domainInput *domain.CommentVote) (*domain.Comment, error) {
-	comment, err := s.storage.VoteCommentIfNotDeleted(ctx, domainInput)
+	// Users vote only on their own behalf
+	if err := s.roles.AuthorizeOwner(ctx, domainInput.VoterID); err != nil {
+		return nil, err
+	}
+	voterID, _ := auth.UserID(ctx) // authorized user is always authenticated
+
+	comment, err := s.storage.GetComment(ctx, domainInput.ID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get comment: %w", err)
+	}
+	if err := s.checkBanned(ctx, comment, voterID); err != nil {
+		return nil, err
+	}
+
+	comment, err = s.storage.VoteCommentIfNotDeleted(ctx, domainInput)
 	if err != nil {
 		return nil, fmt.Errorf("storage failed to vote comment: %w", err)
 	}
 	return comment, nil
 }
 
+// DeleteComment deletes comment on behalf of its author or moderator of community of its post.
 func (s *Service) DeleteComment(ctx context.Context, domainID int) error {
-	err := s.storage.DeleteComment(ctx, domainID)
+	comment, community, err := s.authorizeOwnerOrModerator(ctx, domainID)
+	if err != nil {
+		return err
+	}
+
+	// Author deleting own comment is not logged
+	var entry *domain.AddModLogEntryInput
+	if actorID, _ := auth.UserID(ctx); actorID != comment.AuthorID {
+		entry = modLogEntry(ctx, domain.ModLogActionDeleteComment, community, domainID)
+	}
+
+	err = s.storage.DeleteComment(ctx, domainID, entry)
 	if err != nil {
 		return fmt.Errorf("storage failed to delete comment: %w", err)
 	}
@@ -37,14 +78,119 @@ func (s *Service) DeleteComment(ctx context.Context, domainID int) error {
 }
 
 func (s *Service) UpdateComment(ctx context.Context, domainInput *domain.UpdateCommentInput) (*domain.Comment, error) {
-	comment, err := s.storage.UpdateCommentIfNotDeleted(ctx, domainInput)
+	comment, err := s.storage.GetComment(ctx, domainInput.ID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get comment: %w", err)
+	}
+	if err := s.roles.AuthorizeOwner(ctx, comment.AuthorID); err != nil {
+		return nil, err
+	}
+	if err := s.checkBanned(ctx, comment, comment.AuthorID); err != nil {
+		return nil, err
+	}
+	domainInput.EditorID = comment.AuthorID
+
+	comment, err = s.storage.UpdateCommentIfNotDeleted(ctx, domainInput)
 	if err != nil {
 		return nil, fmt.Errorf("storage failed to update comment: %w", err)
 	}
 	return comment, nil
 }
 
-func (s *Service) CreateComment(ctx context.Context, domainInput *domain.CreateCommentInput) (*domain.Comment, error) {
+// GetRevisions lists versions of comment replaced by edits, oldest first.
+// Available to author of comment and moderators of community of its post.
+func (s *Service) GetRevisions(ctx context.Context, id int) ([]*domain.CommentRevision, error) {
+	if _, _, err := s.authorizeOwnerOrModerator(ctx, id); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.storage.GetCommentRevisions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get comment revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetRevisionDiff returns unified diff between versions of comment numbered as revisions, with the current version last.
+// Diff goes to the current version when to is nil, text of deleted comment is empty.
+func (s *Service) GetRevisionDiff(ctx context.Context, id, from int, to *int) (string, error) {
+	comment, _, err := s.authorizeOwnerOrModerator(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	revisions, err := s.storage.GetCommentRevisions(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("storage failed to get comment revisions: %w", err)
+	}
+
+	versions := make([]string, 0, len(revisions)+1)
+	for _, r := range revisions {
+		versions = append(versions, r.Text)
+	}
+	var current string
+	if comment.Text != nil {
+		current = *comment.Text
+	}
+	versions = append(versions, current)
+
+	last := len(versions)
+	if to != nil {
+		last = *to
+	}
+	diff, ok := textdiff.Versions(versions, from, last)
+	if !ok {
+		return "", errs.RevisionNotFound
+	}
+	return diff, nil
+}
+
+// CreateComment returns replayed true when comment was created earlier by request with the same idempotency key.
+func (s *Service) CreateComment(ctx context.Context, domainInput *domain.CreateCommentInput) (comment *domain.Comment, replayed bool, err error) {
+	// Users comment only on their own behalf
+	if err := s.roles.AuthorizeOwner(ctx, domainInput.AuthorID); err != nil {
+		return nil, false, err
+	}
+	authorID, _ := auth.UserID(ctx) // authorized user is always authenticated
+
+	post, err := s.storage.GetPost(ctx, domainInput.PostID)
+	if err != nil {
+		return nil, false, fmt.Errorf("storage failed to get post: %w", err)
+	}
+	if err := s.bans.CheckBanned(ctx, post.Community, authorID); err != nil {
+		return nil, false, err
+	}
+
+	if domainInput.IdempotencyKey == nil {
+		comment, err := s.createComment(ctx, domainInput)
+		return comment, false, err
+	}
+
+	parentID := ""
+	if domainInput.ParentID != nil {
+		parentID = strconv.Itoa(*domainInput.ParentID)
+	}
+	fingerprint := idempotency.Fingerprint(strconv.Itoa(domainInput.PostID), parentID, domainInput.Text)
+	id, replayed, err := s.idempotency.Do(ctx, authorID, domain.IdempotencyCreateComment, *domainInput.IdempotencyKey, fingerprint,
+		func() (int, error) {
+			comment, err := s.createComment(ctx, domainInput)
+			if err != nil {
+				return 0, err
+			}
+			return comment.ID, nil
+		})
+	if err != nil {
+		return nil, false, err
+	}
+
+	comment, err = s.GetComment(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	return comment, replayed, nil
+}
+
+func (s *Service) createComment(ctx context.Context, domainInput *domain.CreateCommentInput) (*domain.Comment, error) {
 	comment, err := s.storage.CreateComment(ctx, domainInput)
 	if err != nil {
 		return nil, fmt.Errorf("storage failed to create comment: %w", err)
@@ -52,6 +198,223 @@ func (s *Service) CreateComment(ctx context.Context, domainInput *domain.CreateC
 	return comment, nil
 }
 
-func NewService(storage storage.Storage) *Service {
-	return &Service{storage}
+func (s *Service) GetComments(ctx context.Context, q *domain.CommentsInput) (*domain.CommentConnection, error) {
+	var page *domain.CommentsPage
+
+	switch q.Sort {
+	case domain.SortOrderRating:
+		var cursor *domain.PostRatingCursor
+		if q.Cursor != nil {
+			c, err := cursorcoder.DecodeRatingID(*q.Cursor)
+			if err != nil || c == nil {
+				return nil, errs.InvalidCursor
+			}
+			cursor = c
+		}
+
+		p, err := s.storage.GetCommentsSortedByRating(ctx, auth.ViewerID(ctx), q.PostID, q.ParentID, q.Limit, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("storage failed to get comments sorted by rating: %w", err)
+		}
+		page = p
+
+	case domain.SortOrderNew, domain.SortOrderOld:
+		var cursor *domain.PostTimeCursor
+		if q.Cursor != nil {
+			c, err := cursorcoder.DecodeTimeID(*q.Cursor)
+			if err != nil || c == nil {
+				return nil, errs.InvalidCursor
+			}
+			cursor = c
+		}
+
+		newFirst := q.Sort == domain.SortOrderNew
+		p, err := s.storage.GetCommentsSortedByTime(ctx, auth.ViewerID(ctx), q.PostID, q.ParentID, q.Limit, cursor, newFirst)
+		if err != nil {
+			return nil, fmt.Errorf("storage failed to get comments sorted by time: %w", err)
+		}
+		page = p
+
+	case domain.SortOrderBest:
+		var cursor *domain.CommentBestCursor
+		if q.Cursor != nil {
+			c, err := cursorcoder.DecodeScoreID(*q.Cursor)
+			if err != nil {
+				return nil, errs.InvalidCursor
+			}
+			cursor = c
+		}
+
+		p, err := s.storage.GetCommentsSortedByBest(ctx, auth.ViewerID(ctx), q.PostID, q.ParentID, q.Limit, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("storage failed to get comments sorted by best: %w", err)
+		}
+		page = p
+
+	default:
+		return nil, fmt.Errorf("unknown sort order %q", q.Sort)
+	}
+
+	return commentConnection(page, q.Sort), nil
+}
+
+// GetCommentsFirstPages returns first page of comments for every parent, connections are ordered as parents.
+func (s *Service) GetCommentsFirstPages(ctx context.Context, parents []domain.CommentsParent, sort domain.SortOrder, limit int32) ([]*domain.CommentConnection, error) {
+	pages, err := s.storage.GetCommentsFirstPages(ctx, auth.ViewerID(ctx), parents, sort, limit)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get comments first pages: %w", err)
+	}
+
+	connections := make([]*domain.CommentConnection, len(pages))
+	for i, page := range pages {
+		connections[i] = commentConnection(page, sort)
+	}
+	return connections, nil
+}
+
+// commentConnection builds connection with cursors matching sort order of page.
+func commentConnection(page *domain.CommentsPage, sort domain.SortOrder) *domain.CommentConnection {
+	edges := make([]*domain.CommentEdge, len(page.Comments))
+	for i, c := range page.Comments {
+		var cursor string
+		switch sort {
+		case domain.SortOrderRating:
+			cursor = cursorcoder.EncodeRatingID(c.Rating, c.ID)
+		case domain.SortOrderBest:
+			cursor = cursorcoder.EncodeScoreID(c.BestScore, c.ID)
+		default:
+			cursor = cursorcoder.EncodeTimeID(c.CreatedAt, c.ID)
+		}
+		edges[i] = &domain.CommentEdge{
+			Cursor:  &cursor,
+			Comment: c,
+		}
+	}
+
+	connection := &domain.CommentConnection{
+		Edges: edges,
+		PageInfo: &domain.PageInfo{
+			HasNext:   page.HasNext,
+			EndCursor: nil,
+		},
+	}
+	if len(edges) > 0 {
+		connection.PageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+	return connection
+}
+
+func NewService(storage storage.Storage, roles *role.Service, bans *ban.Service, idempotency *idempotency.Service) *Service {
+	return &Service{storage: storage, roles: roles, bans: bans, idempotency: idempotency}
+}
+
+// GetCommentsByIDs returns existing comments among ids, missing ones are omitted.
+func (s *Service) GetCommentsByIDs(ctx context.Context, ids []int) ([]*domain.Comment, error) {
+	comments, err := s.storage.GetCommentsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get comments by ids: %w", err)
+	}
+	return comments, nil
+}
+
+// GetMyVotes returns votes the current user gave to comments, comments without vote are omitted.
+// Anonymous user has no votes.
+func (s *Service) GetMyVotes(ctx context.Context, commentIDs []int) (map[int]int8, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return map[int]int8{}, nil
+	}
+
+	votes, err := s.storage.GetCommentVotes(ctx, userID, commentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get comment votes: %w", err)
+	}
+	return votes, nil
+}
+
+// RemoveComment hides comment from clients on behalf of moderator of community, until moderator restores it.
+func (s *Service) RemoveComment(ctx context.Context, id int, reason *string) (*domain.Comment, error) {
+	community, moderatorID, err := s.authorizeModerator(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &domain.RemoveInput{ID: id, ModeratorID: moderatorID, Reason: reason}
+	comment, err := s.storage.RemoveComment(ctx, input, modLogEntry(ctx, domain.ModLogActionRemoveComment, community, id))
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to remove comment: %w", err)
+	}
+
+	slog.Info("comment removed", "commentID", comment.ID, "moderatorID", moderatorID)
+	return comment, nil
+}
+
+func (s *Service) ApproveComment(ctx context.Context, id int) (*domain.Comment, error) {
+	community, moderatorID, err := s.authorizeModerator(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.storage.ApproveComment(ctx, id, modLogEntry(ctx, domain.ModLogActionApproveComment, community, id))
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to approve comment: %w", err)
+	}
+
+	slog.Info("comment approved", "commentID", comment.ID, "moderatorID", moderatorID)
+	return comment, nil
+}
+
+// authorizeModerator returns community of comment's post and id of the current user
+// if user moderates that community.
+func (s *Service) authorizeModerator(ctx context.Context, id int) (string, uuid.UUID, error) {
+	comment, err := s.storage.GetComment(ctx, id)
+	if err != nil {
+		return "", uuid.Nil, fmt.Errorf("storage failed to get comment: %w", err)
+	}
+	post, err := s.storage.GetPost(ctx, comment.PostID)
+	if err != nil {
+		return "", uuid.Nil, fmt.Errorf("storage failed to get post of comment: %w", err)
+	}
+	if err := s.roles.AuthorizeModerator(ctx, post.Community); err != nil {
+		return "", uuid.Nil, err
+	}
+	moderatorID, _ := auth.UserID(ctx) // authorized user is always authenticated
+	return post.Community, moderatorID, nil
+}
+
+// authorizeOwnerOrModerator returns comment and community of its post if the current user may moderate it.
+func (s *Service) authorizeOwnerOrModerator(ctx context.Context, id int) (*domain.Comment, string, error) {
+	comment, err := s.storage.GetComment(ctx, id)
+	if err != nil {
+		return nil, "", fmt.Errorf("storage failed to get comment: %w", err)
+	}
+	post, err := s.storage.GetPost(ctx, comment.PostID)
+	if err != nil {
+		return nil, "", fmt.Errorf("storage failed to get post of comment: %w", err)
+	}
+	if err := s.roles.AuthorizeOwnerOrModerator(ctx, comment.AuthorID, post.Community); err != nil {
+		return nil, "", err
+	}
+	return comment, post.Community, nil
+}
+
+// checkBanned returns errs.Banned if user is banned in community of comment's post.
+func (s *Service) checkBanned(ctx context.Context, comment *domain.Comment, userID uuid.UUID) error {
+	post, err := s.storage.GetPost(ctx, comment.PostID)
+	if err != nil {
+		return fmt.Errorf("storage failed to get post of comment: %w", err)
+	}
+	return s.bans.CheckBanned(ctx, post.Community, userID)
+}
+
+// modLogEntry describes action of the current user on comment for audit log, storage adds states of comment to it.
+func modLogEntry(ctx context.Context, action domain.ModLogAction, community string, id int) *domain.AddModLogEntryInput {
+	actorID, _ := auth.UserID(ctx) // authorized user is always authenticated
+	return &domain.AddModLogEntryInput{
+		Community:  &community,
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: domain.ModLogTargetComment,
+		TargetID:   strconv.Itoa(id),
+	}
 }
@@ -0,0 +1,137 @@
+// Package idempotency lets clients retry create operations without creating duplicates.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+)
+
+// completeAttempts bounds how many times result of operation is stored before key is left to expire as in progress.
+const completeAttempts = 3
+
+// completeBackoff is delay before the second attempt to store result, it doubles with every next attempt.
+const completeBackoff = 50 * time.Millisecond
+
+type Service struct {
+	storage storage.Storage
+	// How long result of operation is returned to its retries
+	ttl time.Duration
+	// How long key stays reserved by operation that has not stored its result,
+	// it bounds how long retries fail when the first run crashed or could not store result
+	inProgressTimeout time.Duration
+}
+
+func NewService(storage storage.Storage, ttl, inProgressTimeout time.Duration) *Service {
+	return &Service{storage: storage, ttl: ttl, inProgressTimeout: inProgressTimeout}
+}
+
+// Do runs create once per key of user and operation, until key expires retries get id of item created by the first run.
+// Retry with different fingerprint fails with errs.IdempotencyKeyReused,
+// retry while the first run is still in progress fails with errs.IdempotencyInProgress.
+// Key is released if create fails, so that failed operation can be retried.
+// userID must be id of authenticated user, so that nobody can use keys of others.
+func (s *Service) Do(ctx context.Context, userID uuid.UUID, operation domain.IdempotencyOperation, key, fingerprint string,
+	create func() (int, error)) (id int, replayed bool, err error) {
+	existing, err := s.storage.ReserveIdempotencyKey(ctx, &domain.IdempotencyKey{
+		UserID:      userID,
+		Operation:   operation,
+		Key:         key,
+		Fingerprint: fingerprint,
+		ExpiresAt:   time.Now().Add(s.inProgressTimeout),
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("storage failed to reserve idempotency key: %w", err)
+	}
+	if existing != nil {
+		if existing.Fingerprint != fingerprint {
+			return 0, false, errs.IdempotencyKeyReused
+		}
+		if existing.ResultID == nil {
+			return 0, false, errs.IdempotencyInProgress
+		}
+		return *existing.ResultID, true, nil
+	}
+
+	id, err = create()
+	if err != nil {
+		if releaseErr := s.storage.ReleaseIdempotencyKey(ctx, userID, operation, key); releaseErr != nil {
+			slog.Error("failed to release idempotency key", "operation", operation, "error", releaseErr)
+		}
+		return 0, false, err
+	}
+
+	// Item is already created, failing here would make client retry and create duplicate
+	s.complete(ctx, userID, operation, key, id)
+	return id, false, nil
+}
+
+// complete stores result of operation, retrying failures. Result is stored even if client has gone,
+// its retry would otherwise find key in progress until reservation expires.
+func (s *Service) complete(ctx context.Context, userID uuid.UUID, operation domain.IdempotencyOperation, key string, resultID int) {
+	ctx = context.WithoutCancel(ctx)
+	backoff := completeBackoff
+
+	var err error
+	for attempt := 1; attempt <= completeAttempts; attempt++ {
+		err = s.storage.CompleteIdempotencyKey(ctx, userID, operation, key, resultID, time.Now().Add(s.ttl))
+		if err == nil {
+			return
+		}
+		if attempt < completeAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	slog.Error("failed to complete idempotency key, it stays in progress until reservation expires",
+		"operation", operation, "resultID", resultID, "error", err)
+}
+
+// Fingerprint hashes operation input, parts are length-prefixed so that their boundaries matter.
+func Fingerprint(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		_ = binary.Write(h, binary.BigEndian, uint64(len(p)))
+		h.Write([]byte(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// RunPurge deletes expired keys every interval until ctx is done.
+func (s *Service) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) purge(ctx context.Context) {
+	deleted, err := s.storage.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("failed to delete expired idempotency keys", "error", err)
+		}
+		return
+	}
+	if deleted > 0 {
+		slog.Debug("expired idempotency keys deleted", "count", deleted)
+	}
+}
@@ -0,0 +1,102 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage/inmemory"
+)
+
+var user = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// flakyStorage fails first failures attempts to complete key.
+type flakyStorage struct {
+	*inmemory.Storage
+	failures int
+}
+
+func (s *flakyStorage) CompleteIdempotencyKey(ctx context.Context, userID uuid.UUID, operation domain.IdempotencyOperation, key string, resultID int, expiresAt time.Time) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("storage unavailable")
+	}
+	return s.Storage.CompleteIdempotencyKey(ctx, userID, operation, key, resultID, expiresAt)
+}
+
+func createReturning(id int) func() (int, error) {
+	return func() (int, error) { return id, nil }
+}
+
+func TestDoReplaysResult(t *testing.T) {
+	s := NewService(inmemory.New(), time.Hour, time.Minute)
+	ctx := context.Background()
+
+	id, replayed, err := s.Do(ctx, user, domain.IdempotencyCreatePost, "key", "a", createReturning(1))
+	if err != nil || replayed || id != 1 {
+		t.Fatalf("first Do() = %d, %v, %v, want 1, false, nil", id, replayed, err)
+	}
+	id, replayed, err = s.Do(ctx, user, domain.IdempotencyCreatePost, "key", "a", createReturning(2))
+	if err != nil || !replayed || id != 1 {
+		t.Fatalf("retry Do() = %d, %v, %v, want 1, true, nil", id, replayed, err)
+	}
+	_, _, err = s.Do(ctx, user, domain.IdempotencyCreatePost, "key", "b", createReturning(3))
+	if !errors.Is(err, errs.IdempotencyKeyReused) {
+		t.Fatalf("Do() with different fingerprint error = %v, want %v", err, errs.IdempotencyKeyReused)
+	}
+	id, replayed, err = s.Do(ctx, uuid.New(), domain.IdempotencyCreatePost, "key", "b", createReturning(4))
+	if err != nil || replayed || id != 4 {
+		t.Fatalf("Do() of other user = %d, %v, %v, want 4, false, nil", id, replayed, err)
+	}
+}
+
+func TestDoRetriesCompletion(t *testing.T) {
+	s := NewService(&flakyStorage{Storage: inmemory.New(), failures: completeAttempts - 1}, time.Hour, time.Minute)
+	ctx := context.Background()
+
+	if _, _, err := s.Do(ctx, user, domain.IdempotencyCreatePost, "key", "a", createReturning(1)); err != nil {
+		t.Fatalf("first Do() error = %v", err)
+	}
+	id, replayed, err := s.Do(ctx, user, domain.IdempotencyCreatePost, "key", "a", createReturning(2))
+	if err != nil || !replayed || id != 1 {
+		t.Fatalf("retry Do() = %d, %v, %v, want 1, true, nil", id, replayed, err)
+	}
+}
+
+func TestDoReservationExpiresWhenCompletionFails(t *testing.T) {
+	const inProgressTimeout = 50 * time.Millisecond
+	s := NewService(&flakyStorage{Storage: inmemory.New(), failures: completeAttempts}, time.Hour, inProgressTimeout)
+	ctx := context.Background()
+
+	if _, _, err := s.Do(ctx, user, domain.IdempotencyCreatePost, "key", "a", createReturning(1)); err != nil {
+		t.Fatalf("first Do() error = %v", err)
+	}
+	// Key that could not be completed is left in progress until reservation expires
+	time.Sleep(inProgressTimeout)
+
+	id, replayed, err := s.Do(ctx, user, domain.IdempotencyCreatePost, "key", "a", createReturning(2))
+	if err != nil || replayed || id != 2 {
+		t.Fatalf("Do() after reservation expired = %d, %v, %v, want 2, false, nil", id, replayed, err)
+	}
+}
+
+func TestDoInProgress(t *testing.T) {
+	s := NewService(inmemory.New(), time.Hour, time.Minute)
+	ctx := context.Background()
+
+	_, _, err := s.Do(ctx, user, domain.IdempotencyCreatePost, "key", "a", func() (int, error) {
+		_, _, err := s.Do(ctx, user, domain.IdempotencyCreatePost, "key", "a", createReturning(2))
+		if !errors.Is(err, errs.IdempotencyInProgress) {
+			t.Errorf("concurrent Do() error = %v, want %v", err, errs.IdempotencyInProgress)
+		}
+		return 1, nil
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+}
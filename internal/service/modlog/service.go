@@ -0,0 +1,71 @@
+package modlog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/cursorcoder"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/role"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+)
+
+// Service reads audit log, entries are appended by services performing actions.
+type Service struct {
+	storage storage.ModLog
+	roles   *role.Service
+}
+
+func NewService(storage storage.ModLog, roles *role.Service) *Service {
+	return &Service{storage: storage, roles: roles}
+}
+
+// GetModLog lists audit log of community for its moderators, or global log for admins, newest first.
+func (s *Service) GetModLog(ctx context.Context, q *domain.ModLogInput) (*domain.ModLogConnection, error) {
+	if err := s.authorize(ctx, q.Community); err != nil {
+		return nil, err
+	}
+
+	var beforeID *int
+	if q.Cursor != nil {
+		id, err := cursorcoder.DecodeID(*q.Cursor)
+		if err != nil {
+			return nil, errs.InvalidCursor
+		}
+		beforeID = &id
+	}
+
+	filter := &domain.ModLogFilter{
+		Community: q.Community,
+		ActorID:   q.ActorID,
+		Action:    q.Action,
+	}
+	page, err := s.storage.GetModLog(ctx, filter, q.Limit, beforeID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get mod log: %w", err)
+	}
+
+	edges := make([]*domain.ModLogEdge, len(page.Entries))
+	for i, e := range page.Entries {
+		cursor := cursorcoder.EncodeID(e.ID)
+		edges[i] = &domain.ModLogEdge{Cursor: &cursor, Entry: e}
+	}
+
+	connection := &domain.ModLogConnection{
+		Edges:    edges,
+		PageInfo: &domain.PageInfo{HasNext: page.HasNext},
+	}
+	if len(edges) > 0 {
+		connection.PageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+	return connection, nil
+}
+
+// authorize allows global log to admins and log of community to moderators of community.
+func (s *Service) authorize(ctx context.Context, community *string) error {
+	if community == nil {
+		return s.roles.AuthorizeAdmin(ctx)
+	}
+	return s.roles.AuthorizeModerator(ctx, *community)
+}
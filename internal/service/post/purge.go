@@ -0,0 +1,36 @@
+package post
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunPurge permanently deletes posts whose retention period is over, every interval until ctx is done.
+func (s *Service) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) purge(ctx context.Context) {
+	purged, err := s.storage.PurgeDeletedPosts(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("failed to purge deleted posts", "error", err)
+		}
+		return
+	}
+	if purged > 0 {
+		slog.Info("deleted posts purged", "count", purged)
+	}
+}
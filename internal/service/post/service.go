@@ -4,15 +4,33 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/cursorcoder"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/linkurl"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/ban"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/idempotency"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/role"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/textdiff"
 )
 
 type Service struct {
 	storage storage.Storage
+	roles   *role.Service
+	bans    *ban.Service
+	// Deduplicates retried creations
+	idempotency *idempotency.Service
+	// How long deleted post can be restored before it is purged
+	retention time.Duration
+	// How long ago link post counts as duplicate of new post of the same link
+	duplicateLinkWindow time.Duration
 }
 
 func (s *Service) GetPosts(ctx context.Context, q *domain.PostsInput) (*domain.PostConnection, error) {
@@ -30,7 +48,7 @@ func (s *Service) GetPosts(ctx context.Context, q *domain.PostsInput) (*domain.P
 			cursor = c
 		}
 
-		pp, err := s.storage.GetPostsSortedByRating(ctx, q.Limit, cursor)
+		pp, err := s.storage.GetPostsSortedByRating(ctx, auth.ViewerID(ctx), q.Filter, q.Limit, cursor)
 		if err != nil {
 			return nil, fmt.Errorf("storage failed to get posts sorted by rating: %w", err)
 		}
@@ -57,7 +75,7 @@ func (s *Service) GetPosts(ctx context.Context, q *domain.PostsInput) (*domain.P
 		}
 
 		newFirst := q.Sort == domain.SortOrderNew
-		pp, err := s.storage.GetPostsSortedByTime(ctx, q.Limit, cursor, newFirst)
+		pp, err := s.storage.GetPostsSortedByTime(ctx, auth.ViewerID(ctx), q.Filter, q.Limit, cursor, newFirst)
 		if err != nil {
 			return nil, fmt.Errorf("storage failed to get posts sorted by time: %w", err)
 		}
@@ -98,8 +116,8 @@ func (s *Service) GetPosts(ctx context.Context, q *domain.PostsInput) (*domain.P
 	return connection, nil
 }
 
-func NewService(storage storage.Storage) *Service {
-	return &Service{storage}
+func NewService(storage storage.Storage, roles *role.Service, bans *ban.Service, idempotency *idempotency.Service, retention, duplicateLinkWindow time.Duration) *Service {
+	return &Service{storage: storage, roles: roles, bans: bans, idempotency: idempotency, retention: retention, duplicateLinkWindow: duplicateLinkWindow}
 }
 
 func (s *Service) GetPost(ctx context.Context, id int) (*domain.Post, error) {
@@ -111,6 +129,74 @@ func (s *Service) GetPost(ctx context.Context, id int) (*domain.Post, error) {
 }
 
 func (s *Service) CreatePost(ctx context.Context, createPostInput *domain.CreatePostInput) (*domain.Post, error) {
+	// Users post only on their own behalf
+	if err := s.roles.AuthorizeOwner(ctx, createPostInput.AuthorID); err != nil {
+		return nil, err
+	}
+	authorID, _ := auth.UserID(ctx) // authorized user is always authenticated
+	if err := s.bans.CheckBanned(ctx, createPostInput.Community, authorID); err != nil {
+		return nil, err
+	}
+
+	if createPostInput.Kind == domain.PostKindLink {
+		link, err := linkurl.Parse(*createPostInput.URL) // url already validated
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse link url: %w", err)
+		}
+		createPostInput.URL, createPostInput.Domain, createPostInput.NormalizedURL = &link.URL, &link.Domain, &link.Normalized
+	}
+
+	if createPostInput.IdempotencyKey == nil {
+		return s.createPost(ctx, createPostInput)
+	}
+
+	var url string
+	if createPostInput.URL != nil {
+		url = *createPostInput.URL
+	}
+	fingerprint := idempotency.Fingerprint(createPostInput.Community, string(createPostInput.Kind), url, createPostInput.Title, createPostInput.Content)
+	id, replayed, err := s.idempotency.Do(ctx, authorID, domain.IdempotencyCreatePost, *createPostInput.IdempotencyKey, fingerprint,
+		func() (int, error) {
+			post, err := s.createPost(ctx, createPostInput)
+			if err != nil {
+				return 0, err
+			}
+			return post.ID, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	if replayed {
+		slog.Debug("post creation replayed", "postID", id, "authorID", authorID)
+	}
+	return s.GetPost(ctx, id)
+}
+
+// GetDuplicateLinks returns other posts of the same link in community of post made within duplicate window, newest first.
+func (s *Service) GetDuplicateLinks(ctx context.Context, id int) ([]*domain.Post, error) {
+	post, err := s.storage.GetPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get post: %w", err)
+	}
+	if post.NormalizedURL == nil {
+		return []*domain.Post{}, nil
+	}
+
+	posts, err := s.storage.GetRecentLinkPosts(ctx, post.Community, *post.NormalizedURL, time.Now().Add(-s.duplicateLinkWindow))
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get recent link posts: %w", err)
+	}
+
+	duplicates := make([]*domain.Post, 0, len(posts))
+	for _, p := range posts {
+		if p.ID != post.ID {
+			duplicates = append(duplicates, p)
+		}
+	}
+	return duplicates, nil
+}
+
+func (s *Service) createPost(ctx context.Context, createPostInput *domain.CreatePostInput) (*domain.Post, error) {
 	post, err := s.storage.CreatePost(ctx, createPostInput)
 	if err != nil {
 		return nil, fmt.Errorf("storage failed to create post: %w", err)
@@ -121,7 +207,19 @@ func (s *Service) CreatePost(ctx context.Context, createPostInput *domain.Create
 }
 
 func (s *Service) UpdatePost(ctx context.Context, updatePostInput *domain.UpdatePostInput) (*domain.Post, error) {
-	post, err := s.storage.UpdatePost(ctx, updatePostInput)
+	post, err := s.storage.GetPost(ctx, updatePostInput.ID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get post: %w", err)
+	}
+	if err := s.roles.AuthorizeOwner(ctx, post.AuthorID); err != nil {
+		return nil, err
+	}
+	if err := s.bans.CheckBanned(ctx, post.Community, post.AuthorID); err != nil {
+		return nil, err
+	}
+	updatePostInput.EditorID = post.AuthorID
+
+	post, err = s.storage.UpdatePost(ctx, updatePostInput)
 	if err != nil {
 		return nil, fmt.Errorf("storage failed to update post: %w", err)
 	}
@@ -130,8 +228,59 @@ func (s *Service) UpdatePost(ctx context.Context, updatePostInput *domain.Update
 	return post, nil
 }
 
+// GetRevisions lists versions of post replaced by edits, oldest first.
+// Available to author of post and moderators of its community.
+func (s *Service) GetRevisions(ctx context.Context, id int) ([]*domain.PostRevision, error) {
+	if _, err := s.authorizeOwnerOrModerator(ctx, id); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.storage.GetPostRevisions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get post revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetRevisionDiff returns unified diff between versions of post numbered as revisions, with the current version last.
+// Version is title followed by blank line and content. Diff goes to the current version when to is nil.
+func (s *Service) GetRevisionDiff(ctx context.Context, id, from int, to *int) (string, error) {
+	post, err := s.authorizeOwnerOrModerator(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	revisions, err := s.storage.GetPostRevisions(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("storage failed to get post revisions: %w", err)
+	}
+
+	versions := make([]string, 0, len(revisions)+1)
+	for _, r := range revisions {
+		versions = append(versions, r.Title+"\n\n"+r.Content)
+	}
+	versions = append(versions, post.Title+"\n\n"+post.Content)
+
+	last := len(versions)
+	if to != nil {
+		last = *to
+	}
+	diff, ok := textdiff.Versions(versions, from, last)
+	if !ok {
+		return "", errs.RevisionNotFound
+	}
+	return diff, nil
+}
+
+// DeletePost deletes post on behalf of its author or moderator of its community.
+// Post stays restorable during retention period.
 func (s *Service) DeletePost(ctx context.Context, id int) error {
-	err := s.storage.DeletePost(ctx, id)
+	before, err := s.authorizeOwnerOrModerator(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.storage.DeletePost(ctx, id, s.modLogEntry(ctx, domain.ModLogActionDeletePost, before))
 	if err != nil {
 		return fmt.Errorf("storage failed to delete post: %w", err)
 	}
@@ -140,8 +289,40 @@ func (s *Service) DeletePost(ctx context.Context, id int) error {
 	return nil
 }
 
+// RestorePost reverts deletion of post on behalf of its author or moderator of its community.
+func (s *Service) RestorePost(ctx context.Context, id int) (*domain.Post, error) {
+	before, err := s.authorizeOwnerOrModerator(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !before.Deleted {
+		return nil, errs.NotDeleted
+	}
+	if time.Since(*before.DeletedAt) > s.retention {
+		return nil, errs.RestoreExpired
+	}
+
+	post, err := s.storage.RestorePost(ctx, id, s.modLogEntry(ctx, domain.ModLogActionRestorePost, before))
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to restore post: %w", err)
+	}
+
+	slog.Debug("post restored", "postID", post.ID)
+	return post, nil
+}
+
+// SetCommentsRestricted is allowed to author of post and moderators of its community.
 func (s *Service) SetCommentsRestricted(ctx context.Context, internalID int, restricted bool) (*domain.Post, error) {
-	post, err := s.storage.SetCommentsRestricted(ctx, internalID, restricted)
+	before, err := s.authorizeOwnerOrModerator(ctx, internalID)
+	if err != nil {
+		return nil, err
+	}
+
+	action := domain.ModLogActionUnrestrictComments
+	if restricted {
+		action = domain.ModLogActionRestrictComments
+	}
+	post, err := s.storage.SetCommentsRestricted(ctx, internalID, restricted, s.modLogEntry(ctx, action, before))
 	if err != nil {
 		return nil, fmt.Errorf("storage failed to set comments restricted: %w", err)
 	}
@@ -151,9 +332,124 @@ func (s *Service) SetCommentsRestricted(ctx context.Context, internalID int, res
 }
 
 func (s *Service) VotePost(ctx context.Context, internalInput *domain.PostVote) (*domain.Post, error) {
-	post, err := s.storage.VotePost(ctx, internalInput)
+	// Users vote only on their own behalf
+	if err := s.roles.AuthorizeOwner(ctx, internalInput.VoterID); err != nil {
+		return nil, err
+	}
+	voterID, _ := auth.UserID(ctx) // authorized user is always authenticated
+
+	post, err := s.storage.GetPost(ctx, internalInput.ID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get post: %w", err)
+	}
+	if err := s.bans.CheckBanned(ctx, post.Community, voterID); err != nil {
+		return nil, err
+	}
+
+	post, err = s.storage.VotePost(ctx, internalInput)
 	if err != nil {
 		return nil, fmt.Errorf("storage failed to vote post: %w", err)
 	}
 	return post, nil
 }
+
+// GetPostsByIDs returns existing posts among ids, missing ones are omitted.
+func (s *Service) GetPostsByIDs(ctx context.Context, ids []int) ([]*domain.Post, error) {
+	posts, err := s.storage.GetPostsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get posts by ids: %w", err)
+	}
+	return posts, nil
+}
+
+// GetMyVotes returns votes the current user gave to posts, posts without vote are omitted.
+// Anonymous user has no votes.
+func (s *Service) GetMyVotes(ctx context.Context, postIDs []int) (map[int]int8, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return map[int]int8{}, nil
+	}
+
+	votes, err := s.storage.GetPostVotes(ctx, userID, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get post votes: %w", err)
+	}
+	return votes, nil
+}
+
+// RemovePost hides post from clients on behalf of moderator of its community, until moderator restores it.
+func (s *Service) RemovePost(ctx context.Context, id int, reason *string) (*domain.Post, error) {
+	before, moderatorID, err := s.authorizeModerator(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &domain.RemoveInput{ID: id, ModeratorID: moderatorID, Reason: reason}
+	post, err := s.storage.RemovePost(ctx, input, s.modLogEntry(ctx, domain.ModLogActionRemovePost, before))
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to remove post: %w", err)
+	}
+
+	slog.Info("post removed", "postID", post.ID, "moderatorID", moderatorID)
+	return post, nil
+}
+
+func (s *Service) ApprovePost(ctx context.Context, id int) (*domain.Post, error) {
+	before, moderatorID, err := s.authorizeModerator(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.storage.ApprovePost(ctx, id, s.modLogEntry(ctx, domain.ModLogActionApprovePost, before))
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to approve post: %w", err)
+	}
+
+	slog.Info("post approved", "postID", post.ID, "moderatorID", moderatorID)
+	return post, nil
+}
+
+// authorizeModerator returns post and id of the current user if user moderates community of post.
+func (s *Service) authorizeModerator(ctx context.Context, id int) (*domain.Post, uuid.UUID, error) {
+	post, err := s.storage.GetPost(ctx, id)
+	if err != nil {
+		return nil, uuid.Nil, fmt.Errorf("storage failed to get post: %w", err)
+	}
+	if err := s.roles.AuthorizeModerator(ctx, post.Community); err != nil {
+		return nil, uuid.Nil, err
+	}
+	moderatorID, _ := auth.UserID(ctx) // authorized user is always authenticated
+	return post, moderatorID, nil
+}
+
+// authorizeOwnerOrModerator returns post if the current user may moderate it.
+func (s *Service) authorizeOwnerOrModerator(ctx context.Context, id int) (*domain.Post, error) {
+	post, err := s.storage.GetPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get post: %w", err)
+	}
+	if err := s.roles.AuthorizeOwnerOrModerator(ctx, post.AuthorID, post.Community); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// modLogEntry describes action of the current user on post for audit log, storage adds states of post to it.
+// Actions author may take on own post are logged only when moderator takes them, nil is returned otherwise.
+func (s *Service) modLogEntry(ctx context.Context, action domain.ModLogAction, post *domain.Post) *domain.AddModLogEntryInput {
+	actorID, _ := auth.UserID(ctx) // authorized user is always authenticated
+	if actorID == post.AuthorID && !moderatorOnly(action) {
+		return nil
+	}
+	return &domain.AddModLogEntryInput{
+		Community:  &post.Community,
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: domain.ModLogTargetPost,
+		TargetID:   strconv.Itoa(post.ID),
+	}
+}
+
+func moderatorOnly(action domain.ModLogAction) bool {
+	return action == domain.ModLogActionRemovePost || action == domain.ModLogActionApprovePost
+}
@@ -0,0 +1,202 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/ban"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/idempotency"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/role"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage/inmemory"
+)
+
+var (
+	admin  = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	banned = uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	other  = uuid.MustParse("00000000-0000-0000-0000-000000000003")
+)
+
+// newTestService returns post service over in-memory storage where user banned is banned globally.
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	storage := inmemory.New()
+	roles := role.NewService(storage, []uuid.UUID{admin})
+	bans := ban.NewService(storage, roles)
+	if _, err := bans.BanUser(auth.WithUserID(context.Background(), admin), &domain.BanInput{UserID: banned}); err != nil {
+		t.Fatalf("failed to ban user: %v", err)
+	}
+	return NewService(storage, roles, bans, idempotency.NewService(storage, time.Hour, time.Minute), time.Hour, time.Hour)
+}
+
+func TestCreatePostChecksCaller(t *testing.T) {
+	tests := []struct {
+		name     string
+		caller   *uuid.UUID
+		authorID uuid.UUID
+		want     error
+	}{
+		{"anonymous", nil, other, errs.Unauthenticated},
+		{"banned user posting as other user", &banned, other, errs.Forbidden},
+		{"banned user posting as self", &banned, banned, errs.Banned},
+		{"user posting as self", &other, other, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			ctx := context.Background()
+			if tt.caller != nil {
+				ctx = auth.WithUserID(ctx, *tt.caller)
+			}
+
+			_, err := s.CreatePost(ctx, &domain.CreatePostInput{
+				AuthorID:  tt.authorID,
+				Community: "golang",
+				Kind:      domain.PostKindText,
+				Title:     "title",
+				Content:   "content",
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("CreatePost() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVotePostChecksCaller(t *testing.T) {
+	s := newTestService(t)
+	post, err := s.CreatePost(auth.WithUserID(context.Background(), other), &domain.CreatePostInput{
+		AuthorID:  other,
+		Community: "golang",
+		Kind:      domain.PostKindText,
+		Title:     "title",
+		Content:   "content",
+	})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	_, err = s.VotePost(auth.WithUserID(context.Background(), banned), &domain.PostVote{Vote: domain.Vote{ID: post.ID, VoterID: other, Value: 1}})
+	if !errors.Is(err, errs.Forbidden) {
+		t.Fatalf("VotePost() as other user error = %v, want %v", err, errs.Forbidden)
+	}
+	_, err = s.VotePost(auth.WithUserID(context.Background(), banned), &domain.PostVote{Vote: domain.Vote{ID: post.ID, VoterID: banned, Value: 1}})
+	if !errors.Is(err, errs.Banned) {
+		t.Fatalf("VotePost() as banned user error = %v, want %v", err, errs.Banned)
+	}
+}
+
+func TestRemovedPostRejectsEditsAndVotes(t *testing.T) {
+	s := newTestService(t)
+	authorCtx := auth.WithUserID(context.Background(), other)
+	post, err := s.CreatePost(authorCtx, &domain.CreatePostInput{
+		AuthorID:  other,
+		Community: "golang",
+		Kind:      domain.PostKindText,
+		Title:     "title",
+		Content:   "content",
+	})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	if _, err := s.RemovePost(auth.WithUserID(context.Background(), admin), post.ID, nil); err != nil {
+		t.Fatalf("failed to remove post: %v", err)
+	}
+
+	content := "rewritten"
+	_, err = s.UpdatePost(authorCtx, &domain.UpdatePostInput{ID: post.ID, Content: &content})
+	if !errors.Is(err, errs.PostRemoved) {
+		t.Errorf("UpdatePost() of removed post error = %v, want %v", err, errs.PostRemoved)
+	}
+	_, err = s.VotePost(authorCtx, &domain.PostVote{Vote: domain.Vote{ID: post.ID, VoterID: other, Value: 1}})
+	if !errors.Is(err, errs.PostRemoved) {
+		t.Errorf("VotePost() of removed post error = %v, want %v", err, errs.PostRemoved)
+	}
+}
+
+func TestModerationIsLoggedWithAction(t *testing.T) {
+	s := newTestService(t)
+	authorCtx := auth.WithUserID(context.Background(), other)
+	adminCtx := auth.WithUserID(context.Background(), admin)
+	post, err := s.CreatePost(authorCtx, &domain.CreatePostInput{
+		AuthorID:  other,
+		Community: "golang",
+		Kind:      domain.PostKindText,
+		Title:     "title",
+		Content:   "content",
+	})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	if _, err := s.RemovePost(adminCtx, post.ID, nil); err != nil {
+		t.Fatalf("failed to remove post: %v", err)
+	}
+	// Failed action leaves no entry
+	if _, err := s.RestorePost(adminCtx, post.ID); !errors.Is(err, errs.NotDeleted) {
+		t.Fatalf("RestorePost() of not deleted post error = %v, want %v", err, errs.NotDeleted)
+	}
+	// Author deleting own post is not logged
+	if err := s.DeletePost(authorCtx, post.ID); err != nil {
+		t.Fatalf("failed to delete post: %v", err)
+	}
+
+	community := "golang"
+	page, err := s.storage.GetModLog(context.Background(), &domain.ModLogFilter{Community: &community}, 10, nil)
+	if err != nil {
+		t.Fatalf("failed to get mod log: %v", err)
+	}
+	if len(page.Entries) != 1 {
+		t.Fatalf("got %d mod log entries, want 1", len(page.Entries))
+	}
+	e := page.Entries[0]
+	if e.Action != domain.ModLogActionRemovePost || e.ActorID != admin {
+		t.Errorf("entry action = %s by %s, want %s by %s", e.Action, e.ActorID, domain.ModLogActionRemovePost, admin)
+	}
+	wantBefore := `{"deleted":false,"removed":false,"commentsRestricted":false}`
+	wantAfter := `{"deleted":false,"removed":true,"commentsRestricted":false}`
+	if string(e.Before) != wantBefore || string(e.After) != wantAfter {
+		t.Errorf("entry states = %s -> %s, want %s -> %s", e.Before, e.After, wantBefore, wantAfter)
+	}
+}
+
+func TestPostsByDomainSkipRemovedPosts(t *testing.T) {
+	s := newTestService(t)
+	authorCtx := auth.WithUserID(context.Background(), other)
+	var ids []int
+	for _, link := range []string{"https://go.dev/doc", "https://www.go.dev/blog"} {
+		post, err := s.CreatePost(authorCtx, &domain.CreatePostInput{
+			AuthorID:  other,
+			Community: "golang",
+			Kind:      domain.PostKindLink,
+			Title:     "title",
+			URL:       &link,
+		})
+		if err != nil {
+			t.Fatalf("failed to create link post: %v", err)
+		}
+		ids = append(ids, post.ID)
+	}
+	if _, err := s.RemovePost(auth.WithUserID(context.Background(), admin), ids[0], nil); err != nil {
+		t.Fatalf("failed to remove post: %v", err)
+	}
+
+	postDomain := "go.dev"
+	conn, err := s.GetPosts(context.Background(), &domain.PostsInput{
+		Sort:   domain.SortOrderNew,
+		Limit:  10,
+		Filter: domain.PostsFilter{Domain: &postDomain},
+	})
+	if err != nil {
+		t.Fatalf("GetPosts() error = %v", err)
+	}
+	if len(conn.Edges) != 1 || conn.Edges[0].Post.ID != ids[1] {
+		t.Errorf("GetPosts() of domain returned %d posts, want only post %d", len(conn.Edges), ids[1])
+	}
+}
@@ -0,0 +1,143 @@
+// Package preview fetches previews of link posts in background, so that creating post never waits for linked page.
+package preview
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/unfurl"
+)
+
+type Service struct {
+	storage storage.Storage
+	fetcher unfurl.Fetcher
+	// Previews older than ttl are fetched again for new posts of the same link
+	ttl time.Duration
+	// Number of pages fetched at once
+	workers int
+	wake    chan struct{}
+}
+
+func NewService(storage storage.Storage, fetcher unfurl.Fetcher, ttl time.Duration, workers int) *Service {
+	return &Service{
+		storage: storage,
+		fetcher: fetcher,
+		ttl:     ttl,
+		workers: max(workers, 1),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// GetPreviews returns previews of given posts, posts without preview are omitted.
+func (s *Service) GetPreviews(ctx context.Context, postIDs []int) (map[int]*domain.LinkPreview, error) {
+	previews, err := s.storage.GetLinkPreviews(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get link previews: %w", err)
+	}
+	return previews, nil
+}
+
+// Notify makes worker look for links to preview without waiting for the next interval.
+func (s *Service) Notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default: // worker is already notified
+	}
+}
+
+// Run fetches previews of links that have none or have stale one, every interval
+// and whenever notified, until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.unfurlPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// unfurlPending previews links batch by batch until none is left.
+func (s *Service) unfurlPending(ctx context.Context) {
+	batch := s.workers * 4
+	for ctx.Err() == nil {
+		links, err := s.storage.GetLinksToPreview(ctx, time.Now().Add(-s.ttl), batch)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.Error("failed to get links to preview", "error", err)
+			}
+			return
+		}
+
+		// Links that failed to save would come back in the next batch
+		if saved := s.unfurlAll(ctx, links); saved < batch {
+			return
+		}
+	}
+}
+
+// unfurlAll fetches links by workers concurrently, it returns number of saved previews.
+func (s *Service) unfurlAll(ctx context.Context, links []*domain.Link) int {
+	queue := make(chan *domain.Link)
+	var saved atomic.Int32
+	var wg sync.WaitGroup
+	for range min(s.workers, len(links)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range queue {
+				if s.unfurl(ctx, link) {
+					saved.Add(1)
+				}
+			}
+		}()
+	}
+
+	for _, link := range links {
+		queue <- link
+	}
+	close(queue)
+	wg.Wait()
+	return int(saved.Load())
+}
+
+// unfurl saves preview of link, failed fetch is saved too so that it is not retried until preview gets stale.
+func (s *Service) unfurl(ctx context.Context, link *domain.Link) bool {
+	preview := &domain.LinkPreview{
+		NormalizedURL: link.NormalizedURL,
+		Status:        domain.LinkPreviewReady,
+		FetchedAt:     time.Now().UTC(),
+	}
+
+	page, err := s.fetcher.Fetch(ctx, link.URL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		slog.Debug("failed to fetch link preview", "url", link.URL, "error", err)
+		preview.Status = domain.LinkPreviewFailed
+	} else {
+		meta := unfurl.Extract(page)
+		preview.Title, preview.Description, preview.ImageURL = meta.Title, meta.Description, meta.ImageURL
+	}
+
+	if err := s.storage.SaveLinkPreview(ctx, preview); err != nil {
+		if ctx.Err() == nil {
+			slog.Error("failed to save link preview", "url", link.URL, "error", err)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package preview
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage/inmemory"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/unfurl"
+)
+
+// localFetcher fetches pages of httptest servers, which unfurl.HTTPFetcher refuses as they listen on loopback.
+type localFetcher struct {
+	client *http.Client
+}
+
+func (f localFetcher) Fetch(ctx context.Context, rawURL string) (*unfurl.Page, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, unfurl.NotHTMLErr
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &unfurl.Page{URL: resp.Request.URL, Body: body}, nil
+}
+
+func createLinkPost(t *testing.T, storage *inmemory.Storage, rawURL string) *domain.Post {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("failed to parse url: %v", err)
+	}
+	domainName := u.Hostname()
+	post, err := storage.CreatePost(context.Background(), &domain.CreatePostInput{
+		AuthorID:      uuid.New(),
+		Community:     "golang",
+		Kind:          domain.PostKindLink,
+		Title:         "link",
+		URL:           &rawURL,
+		Domain:        &domainName,
+		NormalizedURL: &rawURL,
+	})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	return post
+}
+
+func TestUnfurlPending(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = io.WriteString(w, `<html><head><meta property="og:title" content="Article"><meta property="og:image" content="/a.png"></head></html>`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	storage := inmemory.New()
+	ready := createLinkPost(t, storage, srv.URL+"/article")
+	failed := createLinkPost(t, storage, srv.URL+"/missing")
+	s := NewService(storage, localFetcher{client: srv.Client()}, time.Hour, 2)
+
+	s.unfurlPending(context.Background())
+
+	previews, err := s.GetPreviews(context.Background(), []int{ready.ID, failed.ID})
+	if err != nil {
+		t.Fatalf("GetPreviews() error = %v", err)
+	}
+	p := previews[ready.ID]
+	if p == nil || p.Status != domain.LinkPreviewReady || p.Title == nil || *p.Title != "Article" ||
+		p.ImageURL == nil || *p.ImageURL != srv.URL+"/a.png" {
+		t.Errorf("preview of article = %+v, want ready preview with title and image", p)
+	}
+	if p := previews[failed.ID]; p == nil || p.Status != domain.LinkPreviewFailed {
+		t.Errorf("preview of missing page = %+v, want failed preview", p)
+	}
+
+	// Stored previews, failed ones included, are not fetched again until they get stale
+	links, err := storage.GetLinksToPreview(context.Background(), time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatalf("GetLinksToPreview() error = %v", err)
+	}
+	if len(links) != 0 {
+		t.Errorf("links to preview after unfurl = %d, want 0", len(links))
+	}
+}
@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunPurge deletes buckets that refilled completely, every interval until ctx is done.
+// Deleted bucket is recreated full on the next action, so purge never changes whether action is allowed.
+func (s *Service) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) purge(ctx context.Context) {
+	deleted, err := s.storage.DeleteIdleBuckets(ctx, time.Now().Add(-s.refillTime))
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("failed to delete idle rate limit buckets", "error", err)
+		}
+		return
+	}
+	if deleted > 0 {
+		slog.Debug("idle rate limit buckets deleted", "count", deleted)
+	}
+}
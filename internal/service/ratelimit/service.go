@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+)
+
+// Action is a rate limited kind of mutation.
+type Action string
+
+const (
+	ActionCreatePost    Action = "createPost"
+	ActionCreateComment Action = "createComment"
+	ActionVote          Action = "vote"
+)
+
+type Service struct {
+	storage storage.RateLimit
+	limits  map[Action]domain.RateLimit
+	// Buckets untouched for longer than refillTime are full, so purge may delete them
+	refillTime time.Duration
+}
+
+// NewService creates service limiting given actions, actions without limit are always allowed.
+func NewService(storage storage.RateLimit, limits map[Action]domain.RateLimit) *Service {
+	var refillTime time.Duration
+	for _, limit := range limits {
+		if limit.Interval > 0 {
+			refillTime = max(refillTime, limit.Interval*time.Duration(limit.Burst))
+		}
+	}
+
+	return &Service{
+		storage:    storage,
+		limits:     limits,
+		refillTime: refillTime,
+	}
+}
+
+// Allow spends one action of the caller, identified by user id if authenticated and by ip otherwise.
+// It returns *errs.RateLimitedError when caller has to wait.
+func (s *Service) Allow(ctx context.Context, action Action) error {
+	limit, ok := s.limits[action]
+	if !ok || limit.Interval <= 0 {
+		return nil
+	}
+
+	key := callerKey(ctx, action)
+	allowed, retryAfter, err := s.storage.TakeToken(ctx, key, limit)
+	if err != nil {
+		return fmt.Errorf("storage failed to take rate limit token: %w", err)
+	}
+	if !allowed {
+		slog.Debug("rate limited", "key", key, "retryAfter", retryAfter)
+		return &errs.RateLimitedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+func callerKey(ctx context.Context, action Action) string {
+	if userID, ok := auth.UserID(ctx); ok {
+		return fmt.Sprintf("%s:user:%s", action, userID)
+	}
+	return fmt.Sprintf("%s:ip:%s", action, auth.ClientIP(ctx))
+}
@@ -0,0 +1,220 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/cursorcoder"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/service/role"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+)
+
+type Service struct {
+	storage storage.Storage
+	roles   *role.Service
+}
+
+func NewService(storage storage.Storage, roles *role.Service) *Service {
+	return &Service{storage: storage, roles: roles}
+}
+
+// ReportPost flags post on behalf of the current user.
+func (s *Service) ReportPost(ctx context.Context, postID int, reason domain.ReportReason, text *string) (*domain.Report, error) {
+	reporterID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, errs.Unauthenticated
+	}
+
+	post, err := s.storage.GetPost(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get post: %w", err)
+	}
+	if post.Deleted {
+		return nil, errs.PostDeleted
+	}
+
+	return s.create(ctx, &domain.CreateReportInput{
+		PostID:     post.ID,
+		Community:  post.Community,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Text:       text,
+	})
+}
+
+// ReportComment flags comment on behalf of the current user, deleted comments cannot be reported.
+func (s *Service) ReportComment(ctx context.Context, commentID int, reason domain.ReportReason, text *string) (*domain.Report, error) {
+	reporterID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, errs.Unauthenticated
+	}
+
+	comment, err := s.storage.GetComment(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get comment: %w", err)
+	}
+	if comment.Deleted {
+		return nil, errs.CommentDeleted
+	}
+	post, err := s.storage.GetPost(ctx, comment.PostID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get post of comment: %w", err)
+	}
+
+	return s.create(ctx, &domain.CreateReportInput{
+		PostID:     post.ID,
+		CommentID:  &comment.ID,
+		Community:  post.Community,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Text:       text,
+	})
+}
+
+func (s *Service) create(ctx context.Context, input *domain.CreateReportInput) (*domain.Report, error) {
+	report, err := s.storage.CreateReport(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to create report: %w", err)
+	}
+
+	slog.Debug("content reported", "reportID", report.ID, "postID", report.PostID, "commentID", report.CommentID)
+	return report, nil
+}
+
+// ResolveReport applies moderator decision to report and every other open report of the same content.
+// Removing action removes reported content.
+func (s *Service) ResolveReport(ctx context.Context, id int, action domain.ReportAction) (*domain.Report, error) {
+	resolverID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, errs.Unauthenticated
+	}
+
+	report, err := s.storage.GetReport(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get report: %w", err)
+	}
+	if err := s.roles.AuthorizeModerator(ctx, report.Community); err != nil {
+		return nil, err
+	}
+
+	var status domain.ReportStatus
+	switch action {
+	case domain.ReportActionApprove:
+		status = domain.ReportStatusApproved
+	case domain.ReportActionRemove:
+		status = domain.ReportStatusRemoved
+	case domain.ReportActionDismiss:
+		status = domain.ReportStatusDismissed
+	default:
+		return nil, fmt.Errorf("unknown report action %q", action)
+	}
+
+	targetType, targetID := domain.ModLogTargetPost, report.PostID
+	if report.CommentID != nil {
+		targetType, targetID = domain.ModLogTargetComment, *report.CommentID
+	}
+	entry := &domain.AddModLogEntryInput{
+		Community:  &report.Community,
+		ActorID:    resolverID,
+		Action:     domain.ModLogActionResolveReport,
+		TargetType: targetType,
+		TargetID:   strconv.Itoa(targetID),
+	}
+
+	// Report is resolved first, so that concurrent moderators do not act on the same content twice
+	report, err = s.storage.ResolveReport(ctx, &domain.ResolveReportInput{
+		ID:         id,
+		Status:     status,
+		ResolverID: resolverID,
+	}, entry)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to resolve report: %w", err)
+	}
+
+	if status == domain.ReportStatusRemoved {
+		if err := s.removeContent(ctx, report, resolverID); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Info("report resolved", "reportID", report.ID, "status", report.Status, "resolverID", resolverID)
+	return report, nil
+}
+
+// removeContent removes reported content on behalf of moderator, citing reason of report.
+// Content already gone or deleted by author is left as is.
+func (s *Service) removeContent(ctx context.Context, report *domain.Report, moderatorID uuid.UUID) error {
+	reason := string(report.Reason)
+	input := &domain.RemoveInput{ModeratorID: moderatorID, Reason: &reason}
+	entry := &domain.AddModLogEntryInput{Community: &report.Community, ActorID: moderatorID}
+
+	if report.CommentID != nil {
+		input.ID = *report.CommentID
+		entry.Action, entry.TargetType, entry.TargetID = domain.ModLogActionRemoveComment, domain.ModLogTargetComment, strconv.Itoa(input.ID)
+
+		_, err := s.storage.RemoveComment(ctx, input, entry)
+		if errors.Is(err, errs.CommentNotFound) || errors.Is(err, errs.CommentDeleted) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("storage failed to remove reported comment: %w", err)
+		}
+		return nil
+	}
+
+	input.ID = report.PostID
+	entry.Action, entry.TargetType, entry.TargetID = domain.ModLogActionRemovePost, domain.ModLogTargetPost, strconv.Itoa(input.ID)
+
+	_, err := s.storage.RemovePost(ctx, input, entry)
+	if errors.Is(err, errs.PostNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("storage failed to remove reported post: %w", err)
+	}
+	return nil
+}
+
+// GetModerationQueue lists reports of community for its moderators, oldest first.
+func (s *Service) GetModerationQueue(ctx context.Context, q *domain.ModerationQueueInput) (*domain.ReportConnection, error) {
+	if err := s.roles.AuthorizeModerator(ctx, q.Community); err != nil {
+		return nil, err
+	}
+
+	var afterID *int
+	if q.Cursor != nil {
+		id, err := cursorcoder.DecodeID(*q.Cursor)
+		if err != nil {
+			return nil, errs.InvalidCursor
+		}
+		afterID = &id
+	}
+
+	page, err := s.storage.GetReports(ctx, q.Community, q.Status, q.Limit, afterID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get reports: %w", err)
+	}
+
+	edges := make([]*domain.ReportEdge, len(page.Reports))
+	for i, r := range page.Reports {
+		cursor := cursorcoder.EncodeID(r.ID)
+		edges[i] = &domain.ReportEdge{Cursor: &cursor, Report: r}
+	}
+
+	connection := &domain.ReportConnection{
+		Edges:    edges,
+		PageInfo: &domain.PageInfo{HasNext: page.HasNext},
+	}
+	if len(edges) > 0 {
+		connection.PageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+	return connection, nil
+}
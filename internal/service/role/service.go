@@ -0,0 +1,172 @@
+package role
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+)
+
+type Service struct {
+	storage storage.Storage
+	admins  map[uuid.UUID]struct{}
+}
+
+// NewService creates service granting admin role to given users in every community.
+func NewService(storage storage.Storage, admins []uuid.UUID) *Service {
+	set := make(map[uuid.UUID]struct{}, len(admins))
+	for _, id := range admins {
+		set[id] = struct{}{}
+	}
+	return &Service{
+		storage: storage,
+		admins:  set,
+	}
+}
+
+// RoleIn returns the strongest role of the current user in community, empty role if user has none.
+func (s *Service) RoleIn(ctx context.Context, community string) (domain.Role, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return "", nil
+	}
+	return s.RoleOf(ctx, &community, userID)
+}
+
+// RoleOf returns the strongest role of user in community, or global role when community is nil.
+// Empty role is returned if user has none.
+func (s *Service) RoleOf(ctx context.Context, community *string, userID uuid.UUID) (domain.Role, error) {
+	if _, ok := s.admins[userID]; ok {
+		return domain.RoleAdmin, nil
+	}
+	if community == nil {
+		return "", nil
+	}
+
+	isModerator, err := s.storage.IsModerator(ctx, *community, userID)
+	if err != nil {
+		return "", fmt.Errorf("storage failed to check moderator: %w", err)
+	}
+	if isModerator {
+		return domain.RoleModerator, nil
+	}
+	return "", nil
+}
+
+// CanModerate reports whether the current user is admin or moderator of community.
+func (s *Service) CanModerate(ctx context.Context, community string) (bool, error) {
+	role, err := s.RoleIn(ctx, community)
+	if err != nil {
+		return false, err
+	}
+	return role != "", nil
+}
+
+// AuthorizeOwner allows action to author of content only.
+func (s *Service) AuthorizeOwner(ctx context.Context, authorID uuid.UUID) error {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return errs.Unauthenticated
+	}
+	if userID != authorID {
+		return errs.Forbidden
+	}
+	return nil
+}
+
+// AuthorizeOwnerOrModerator allows action to author of content and to moderators of its community.
+func (s *Service) AuthorizeOwnerOrModerator(ctx context.Context, authorID uuid.UUID, community string) error {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return errs.Unauthenticated
+	}
+	if userID == authorID {
+		return nil
+	}
+	return s.AuthorizeModerator(ctx, community)
+}
+
+// AuthorizeModerator allows action to admins and moderators of community only.
+func (s *Service) AuthorizeModerator(ctx context.Context, community string) error {
+	if _, ok := auth.UserID(ctx); !ok {
+		return errs.Unauthenticated
+	}
+
+	canModerate, err := s.CanModerate(ctx, community)
+	if err != nil {
+		return err
+	}
+	if !canModerate {
+		return errs.Forbidden
+	}
+	return nil
+}
+
+// AddModerator appoints moderator of community, only admins can do it.
+func (s *Service) AddModerator(ctx context.Context, community string, userID uuid.UUID) (*domain.Moderator, error) {
+	if err := s.AuthorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	entry := modLogEntry(ctx, domain.ModLogActionAddModerator, community, userID)
+	mod, err := s.storage.AddModerator(ctx, community, userID, entry)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to add moderator: %w", err)
+	}
+
+	slog.Info("moderator added", "community", community, "userID", userID)
+	return mod, nil
+}
+
+// RemoveModerator dismisses moderator of community, only admins can do it.
+func (s *Service) RemoveModerator(ctx context.Context, community string, userID uuid.UUID) error {
+	if err := s.AuthorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	entry := modLogEntry(ctx, domain.ModLogActionRemoveModerator, community, userID)
+	if err := s.storage.RemoveModerator(ctx, community, userID, entry); err != nil {
+		return fmt.Errorf("storage failed to remove moderator: %w", err)
+	}
+
+	slog.Info("moderator removed", "community", community, "userID", userID)
+	return nil
+}
+
+func (s *Service) GetModerators(ctx context.Context, community string) ([]*domain.Moderator, error) {
+	mods, err := s.storage.GetModerators(ctx, community)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get moderators: %w", err)
+	}
+	return mods, nil
+}
+
+// AuthorizeAdmin allows action to admins only.
+func (s *Service) AuthorizeAdmin(ctx context.Context) error {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return errs.Unauthenticated
+	}
+	if _, ok := s.admins[userID]; !ok {
+		return errs.Forbidden
+	}
+	return nil
+}
+
+// modLogEntry describes change of moderators made by the current user for audit log.
+func modLogEntry(ctx context.Context, action domain.ModLogAction, community string, userID uuid.UUID) *domain.AddModLogEntryInput {
+	actorID, _ := auth.UserID(ctx) // authorized user is always authenticated
+	return &domain.AddModLogEntryInput{
+		Community:  &community,
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: domain.ModLogTargetUser,
+		TargetID:   userID.String(),
+	}
+}
@@ -0,0 +1,136 @@
+package saved
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/cursorcoder"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+)
+
+// Service manages posts and comments the current user saved to read later.
+type Service struct {
+	storage storage.Storage
+}
+
+func NewService(storage storage.Storage) *Service {
+	return &Service{storage: storage}
+}
+
+// SavePost saves post for the current user, saving already saved post does nothing.
+func (s *Service) SavePost(ctx context.Context, id int) (*domain.Post, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, errs.Unauthenticated
+	}
+
+	post, err := s.storage.GetPost(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get post: %w", err)
+	}
+	if post.Deleted {
+		return nil, errs.PostDeleted
+	}
+
+	if _, err := s.storage.SaveItem(ctx, userID, domain.SavedTypePost, id); err != nil {
+		return nil, fmt.Errorf("storage failed to save post: %w", err)
+	}
+	return post, nil
+}
+
+// SaveComment saves comment for the current user, saving already saved comment does nothing.
+func (s *Service) SaveComment(ctx context.Context, id int) (*domain.Comment, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, errs.Unauthenticated
+	}
+
+	comment, err := s.storage.GetComment(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get comment: %w", err)
+	}
+	if comment.Deleted {
+		return nil, errs.CommentDeleted
+	}
+
+	if _, err := s.storage.SaveItem(ctx, userID, domain.SavedTypeComment, id); err != nil {
+		return nil, fmt.Errorf("storage failed to save comment: %w", err)
+	}
+	return comment, nil
+}
+
+// UnsavePost removes post from saved items of the current user, unsaving post that is not saved does nothing.
+func (s *Service) UnsavePost(ctx context.Context, id int) error {
+	return s.unsave(ctx, domain.SavedTypePost, id)
+}
+
+// UnsaveComment removes comment from saved items of the current user, unsaving comment that is not saved does nothing.
+func (s *Service) UnsaveComment(ctx context.Context, id int) error {
+	return s.unsave(ctx, domain.SavedTypeComment, id)
+}
+
+func (s *Service) unsave(ctx context.Context, itemType domain.SavedType, id int) error {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return errs.Unauthenticated
+	}
+
+	if err := s.storage.UnsaveItem(ctx, userID, itemType, id); err != nil {
+		return fmt.Errorf("storage failed to unsave item: %w", err)
+	}
+	return nil
+}
+
+// GetMySaved returns which of given items the current user saved. Anonymous user has no saved items.
+func (s *Service) GetMySaved(ctx context.Context, itemType domain.SavedType, ids []int) (map[int]bool, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return map[int]bool{}, nil
+	}
+
+	saved, err := s.storage.GetSavedIDs(ctx, userID, itemType, ids)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get saved ids: %w", err)
+	}
+	return saved, nil
+}
+
+// GetSaved lists items of the current user, most recently saved first.
+func (s *Service) GetSaved(ctx context.Context, in *domain.SavedInput) (*domain.SavedConnection, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, errs.Unauthenticated
+	}
+
+	var cursor *domain.PostTimeCursor
+	if in.Cursor != nil {
+		var err error
+		cursor, err = cursorcoder.DecodeTimeID(*in.Cursor)
+		if err != nil {
+			return nil, errs.InvalidCursor
+		}
+	}
+
+	page, err := s.storage.GetSavedItems(ctx, userID, in.Type, in.Limit, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get saved items: %w", err)
+	}
+
+	edges := make([]*domain.SavedEdge, len(page.Items))
+	for i, item := range page.Items {
+		cursor := cursorcoder.EncodeTimeID(item.SavedAt, item.ItemID)
+		edges[i] = &domain.SavedEdge{Cursor: &cursor, Item: item}
+	}
+
+	connection := &domain.SavedConnection{
+		Edges:    edges,
+		PageInfo: &domain.PageInfo{HasNext: page.HasNext},
+	}
+	if len(edges) > 0 {
+		connection.PageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+	return connection, nil
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/cursorcoder"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/searchquery"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+)
+
+type Service struct {
+	storage storage.Search
+}
+
+func NewService(storage storage.Search) *Service {
+	return &Service{storage: storage}
+}
+
+// highlight turns matches marked by storage into highlight marks, it is applied to escaped snippet.
+var highlight = strings.NewReplacer(
+	domain.SnippetMatchStart, domain.HighlightStart,
+	domain.SnippetMatchStop, domain.HighlightStop,
+)
+
+// Search finds posts or comments matching words and filters of query, cursors encode position in requested sort order.
+func (s *Service) Search(ctx context.Context, in *domain.SearchInput) (*domain.SearchConnection, error) {
+	text, filter, err := searchquery.Parse(in.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &domain.SearchQuery{
+		Text:   text,
+		Filter: filter,
+		Sort:   in.Sort,
+		Limit:  in.Limit,
+	}
+	if in.Cursor != nil {
+		if err := decodeCursor(q, *in.Cursor); err != nil {
+			return nil, errs.InvalidCursor
+		}
+	}
+
+	var page *domain.SearchPage
+	switch in.Type {
+	case domain.SearchTypePost:
+		page, err = s.storage.SearchPosts(ctx, q)
+		if err != nil {
+			return nil, fmt.Errorf("storage failed to search posts: %w", err)
+		}
+	case domain.SearchTypeComment:
+		page, err = s.storage.SearchComments(ctx, q)
+		if err != nil {
+			return nil, fmt.Errorf("storage failed to search comments: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown search type %q", in.Type)
+	}
+
+	edges := make([]*domain.SearchEdge, len(page.Hits))
+	for i, h := range page.Hits {
+		// Content is plain text, so only highlight marks are left as markup
+		h.Snippet = highlight.Replace(html.EscapeString(h.Snippet))
+		cursor := encodeCursor(h, in.Sort)
+		edges[i] = &domain.SearchEdge{Cursor: &cursor, Hit: h}
+	}
+
+	connection := &domain.SearchConnection{
+		Edges:    edges,
+		PageInfo: &domain.PageInfo{HasNext: page.HasNext},
+	}
+	if len(edges) > 0 {
+		connection.PageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+	return connection, nil
+}
+
+// decodeCursor sets cursor of query sort order.
+func decodeCursor(q *domain.SearchQuery, cursor string) error {
+	switch q.Sort {
+	case domain.SearchSortNew:
+		c, err := cursorcoder.DecodeTimeID(cursor)
+		if err != nil || c == nil {
+			return errs.InvalidCursor
+		}
+		q.TimeCursor = c
+	case domain.SearchSortRating:
+		c, err := cursorcoder.DecodeRatingID(cursor)
+		if err != nil || c == nil {
+			return errs.InvalidCursor
+		}
+		q.RatingCursor = c
+	default:
+		c, err := cursorcoder.DecodeScoreID(cursor)
+		if err != nil {
+			return errs.InvalidCursor
+		}
+		q.RankCursor = &domain.SearchRankCursor{Rank: c.Score, ID: c.ID}
+	}
+	return nil
+}
+
+func encodeCursor(h *domain.SearchHit, sort domain.SearchSort) string {
+	var id int
+	var createdAt time.Time
+	var rating int32
+	if h.Comment != nil {
+		id, createdAt, rating = h.Comment.ID, h.Comment.CreatedAt, h.Comment.Rating
+	} else {
+		id, createdAt, rating = h.Post.ID, h.Post.CreatedAt, h.Post.Rating
+	}
+
+	switch sort {
+	case domain.SearchSortNew:
+		return cursorcoder.EncodeTimeID(createdAt, id)
+	case domain.SearchSortRating:
+		return cursorcoder.EncodeRatingID(rating, id)
+	default:
+		return cursorcoder.EncodeScoreID(h.Rank, id)
+	}
+}
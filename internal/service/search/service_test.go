@@ -0,0 +1,43 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage/inmemory"
+)
+
+func TestSnippetEscapesMarkupOfContent(t *testing.T) {
+	storage := inmemory.New()
+	_, err := storage.CreatePost(context.Background(), &domain.CreatePostInput{
+		AuthorID:  uuid.New(),
+		Community: "golang",
+		Kind:      domain.PostKindText,
+		Title:     "title",
+		Content:   "<mark>gopher</mark> \uE000fake\uE001 <b>gopher</b> end",
+	})
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+
+	conn, err := NewService(storage).Search(context.Background(), &domain.SearchInput{
+		Query: "gopher",
+		Type:  domain.SearchTypePost,
+		Sort:  domain.SearchSortRelevance,
+		Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(conn.Edges) != 1 {
+		t.Fatalf("got %d hits, want 1", len(conn.Edges))
+	}
+
+	want := "title &lt;mark&gt;<mark>gopher</mark>&lt;/mark&gt; fake &lt;b&gt;<mark>gopher</mark>&lt;/b&gt; end"
+	if got := conn.Edges[0].Hit.Snippet; got != want {
+		t.Errorf("snippet = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package visibility
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/auth"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
+)
+
+// Service manages posts hidden and users blocked by the current user.
+// Listings of posts and comments skip them for that user only.
+type Service struct {
+	storage storage.Storage
+}
+
+func NewService(storage storage.Storage) *Service {
+	return &Service{storage: storage}
+}
+
+// HidePost removes post from feeds of the current user, hiding already hidden post does nothing.
+func (s *Service) HidePost(ctx context.Context, id int) error {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return errs.Unauthenticated
+	}
+
+	if _, err := s.storage.GetPost(ctx, id); err != nil {
+		return fmt.Errorf("storage failed to get post: %w", err)
+	}
+
+	if err := s.storage.HidePost(ctx, userID, id); err != nil {
+		return fmt.Errorf("storage failed to hide post: %w", err)
+	}
+	return nil
+}
+
+// UnhidePost returns post to feeds of the current user, unhiding post that is not hidden does nothing.
+func (s *Service) UnhidePost(ctx context.Context, id int) error {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return errs.Unauthenticated
+	}
+
+	if err := s.storage.UnhidePost(ctx, userID, id); err != nil {
+		return fmt.Errorf("storage failed to unhide post: %w", err)
+	}
+	return nil
+}
+
+// BlockUser hides posts and comments of blocked user from the current user.
+// Blocking already blocked user returns existing block.
+func (s *Service) BlockUser(ctx context.Context, blockedID uuid.UUID) (*domain.Block, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, errs.Unauthenticated
+	}
+	if blockedID == userID {
+		return nil, errs.BlockSelf
+	}
+
+	block, err := s.storage.BlockUser(ctx, userID, blockedID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to block user: %w", err)
+	}
+
+	slog.Debug("user blocked", "userID", userID, "blockedID", blockedID)
+	return block, nil
+}
+
+// UnblockUser is idempotent, unblocking user that is not blocked does nothing.
+func (s *Service) UnblockUser(ctx context.Context, blockedID uuid.UUID) error {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return errs.Unauthenticated
+	}
+
+	if err := s.storage.UnblockUser(ctx, userID, blockedID); err != nil {
+		return fmt.Errorf("storage failed to unblock user: %w", err)
+	}
+	return nil
+}
+
+// GetBlockedUsers lists users blocked by the current user, most recently blocked first.
+func (s *Service) GetBlockedUsers(ctx context.Context) ([]*domain.Block, error) {
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return nil, errs.Unauthenticated
+	}
+
+	blocks, err := s.storage.GetBlocks(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get blocks: %w", err)
+	}
+	return blocks, nil
+}
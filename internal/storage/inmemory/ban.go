@@ -0,0 +1,102 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+func (s *Storage) BanUser(ctx context.Context, input *domain.BanInput, entry *domain.AddModLogEntryInput) (*domain.Ban, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := scopeKey(input.Community)
+	bans, ok := s.bans[key]
+	if !ok {
+		bans = make(map[uuid.UUID]*domain.Ban)
+		s.bans[key] = bans
+	}
+
+	now := time.Now().UTC()
+	var before *domain.Ban
+	if b, ok := bans[input.UserID]; ok && b.Active(now) {
+		before = b
+	}
+
+	ban := &domain.Ban{
+		Community: input.Community,
+		UserID:    input.UserID,
+		Reason:    input.Reason,
+		BannedBy:  input.BannedBy,
+		CreatedAt: now,
+		ExpiresAt: input.ExpiresAt,
+	}
+	bans[input.UserID] = ban
+	if err := s.addModLogEntry(entry, domain.BanStateOf(before), domain.BanStateOf(ban)); err != nil {
+		return nil, err
+	}
+
+	banCopy := *ban
+	return &banCopy, nil
+}
+
+func (s *Storage) UnbanUser(ctx context.Context, community *string, userID uuid.UUID, entry *domain.AddModLogEntryInput) (*domain.Ban, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bans := s.bans[scopeKey(community)]
+	ban, ok := bans[userID]
+	if !ok || !ban.Active(time.Now()) {
+		return nil, errs.BanNotFound
+	}
+	delete(bans, userID)
+	if err := s.addModLogEntry(entry, domain.BanStateOf(ban), domain.BanStateOf(nil)); err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+func (s *Storage) GetActiveBan(ctx context.Context, community string, userID uuid.UUID) (*domain.Ban, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	for _, key := range []string{community, ""} {
+		if ban, ok := s.bans[key][userID]; ok && ban.Active(now) {
+			banCopy := *ban
+			return &banCopy, nil
+		}
+	}
+	return nil, errs.BanNotFound
+}
+
+func (s *Storage) GetActiveBans(ctx context.Context, community *string) ([]*domain.Ban, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	bans := make([]*domain.Ban, 0)
+	for _, ban := range s.bans[scopeKey(community)] {
+		if ban.Active(now) {
+			banCopy := *ban
+			bans = append(bans, &banCopy)
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].CreatedAt.After(bans[j].CreatedAt)
+	})
+	return bans, nil
+}
+
+// banKey maps global bans to empty community, which is never valid community name.
+func scopeKey(community *string) string {
+	if community == nil {
+		return ""
+	}
+	return *community
+}
@@ -0,0 +1,67 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+type idempotencyKey struct {
+	userID    uuid.UUID
+	operation domain.IdempotencyOperation
+	key       string
+}
+
+func (s *Storage) ReserveIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) (*domain.IdempotencyKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := idempotencyKey{key.UserID, key.Operation, key.Key}
+	if existing, ok := s.idempotencyKeys[k]; ok && existing.ExpiresAt.After(time.Now()) {
+		existingCopy := *existing
+		return &existingCopy, nil
+	}
+
+	reserved := *key
+	reserved.ResultID = nil
+	reserved.CreatedAt = time.Now().UTC()
+	s.idempotencyKeys[k] = &reserved
+	return nil, nil
+}
+
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, userID uuid.UUID, operation domain.IdempotencyOperation, key string, resultID int, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if reserved, ok := s.idempotencyKeys[idempotencyKey{userID, operation, key}]; ok && reserved.ResultID == nil {
+		reserved.ResultID = &resultID
+		reserved.ExpiresAt = expiresAt
+	}
+	return nil
+}
+
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, userID uuid.UUID, operation domain.IdempotencyOperation, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.idempotencyKeys, idempotencyKey{userID, operation, key})
+	return nil
+}
+
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	deleted := 0
+	for k, key := range s.idempotencyKeys {
+		if !key.ExpiresAt.After(now) {
+			delete(s.idempotencyKeys, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,75 @@
+package inmemory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+// addModLogEntry appends entry with given states of its target to audit log, nil entry is skipped.
+// Caller holds write lock, so that entry is added along with action it records.
+func (s *Storage) addModLogEntry(entry *domain.AddModLogEntryInput, before, after any) error {
+	if entry == nil {
+		return nil
+	}
+
+	beforeJSON, err := marshalState(before)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state before action: %w", err)
+	}
+	afterJSON, err := marshalState(after)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state after action: %w", err)
+	}
+
+	s.modLog = append(s.modLog, &domain.ModLogEntry{
+		ID:         len(s.modLog) + 1,
+		Community:  entry.Community,
+		ActorID:    entry.ActorID,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		CreatedAt:  time.Now().UTC(),
+	})
+	return nil
+}
+
+func (s *Storage) GetModLog(ctx context.Context, filter *domain.ModLogFilter, limit int32, beforeID *int) (*domain.ModLogPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Entries are ordered by id, so log is scanned backwards for newest first
+	var entries []*domain.ModLogEntry
+	for i := len(s.modLog) - 1; i >= 0 && len(entries) <= int(limit); i-- {
+		e := s.modLog[i]
+		if beforeID != nil && e.ID >= *beforeID {
+			continue
+		}
+		if scopeKey(e.Community) != scopeKey(filter.Community) ||
+			filter.ActorID != nil && e.ActorID != *filter.ActorID ||
+			filter.Action != nil && e.Action != *filter.Action {
+			continue
+		}
+		entryCopy := *e
+		entries = append(entries, &entryCopy)
+	}
+
+	hasNext := len(entries) > int(limit)
+	if hasNext {
+		entries = entries[:limit]
+	}
+	return &domain.ModLogPage{Entries: entries, HasNext: hasNext}, nil
+}
+
+// marshalState returns nil for nil state.
+func marshalState(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	return json.Marshal(state)
+}
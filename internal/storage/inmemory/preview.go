@@ -0,0 +1,68 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func (s *Storage) SaveLinkPreview(ctx context.Context, preview *domain.LinkPreview) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previewCopy := *preview
+	s.linkPreviews[preview.NormalizedURL] = &previewCopy
+	return nil
+}
+
+func (s *Storage) GetLinkPreviews(ctx context.Context, postIDs []int) (map[int]*domain.LinkPreview, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	previews := make(map[int]*domain.LinkPreview, len(postIDs))
+	for _, id := range postIDs {
+		post, ok := s.posts[id]
+		if !ok || post.NormalizedURL == nil {
+			continue
+		}
+		if preview, ok := s.linkPreviews[*post.NormalizedURL]; ok {
+			previewCopy := *preview
+			previews[id] = &previewCopy
+		}
+	}
+	return previews, nil
+}
+
+func (s *Storage) GetLinksToPreview(ctx context.Context, staleBefore time.Time, limit int) ([]*domain.Link, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Newest posts first, their links are the most likely to be viewed
+	posts := make([]*domain.Post, 0)
+	for _, p := range s.posts {
+		if p.NormalizedURL == nil || p.Deleted {
+			continue
+		}
+		preview, ok := s.linkPreviews[*p.NormalizedURL]
+		if ok && (!preview.FetchedAt.Before(staleBefore) || !preview.FetchedAt.Before(p.CreatedAt)) {
+			continue
+		}
+		posts = append(posts, p)
+	}
+	sort.Slice(posts, func(i, j int) bool { return posts[i].ID > posts[j].ID })
+
+	seen := make(map[string]bool)
+	links := make([]*domain.Link, 0, limit)
+	for _, p := range posts {
+		if len(links) == limit {
+			break
+		}
+		if !seen[*p.NormalizedURL] {
+			seen[*p.NormalizedURL] = true
+			links = append(links, &domain.Link{URL: *p.URL, NormalizedURL: *p.NormalizedURL})
+		}
+	}
+	return links, nil
+}
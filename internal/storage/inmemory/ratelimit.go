@@ -0,0 +1,49 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+func (s *Storage) TakeToken(ctx context.Context, key string, limit domain.RateLimit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	refilled := float64(now.Sub(b.updatedAt)) / float64(limit.Interval)
+	b.tokens = min(float64(limit.Burst), b.tokens+refilled)
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) * float64(limit.Interval)), nil
+	}
+	b.tokens--
+	return true, 0, nil
+}
+
+func (s *Storage) DeleteIdleBuckets(ctx context.Context, updatedBefore time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key, b := range s.buckets {
+		if b.updatedAt.Before(updatedBefore) {
+			delete(s.buckets, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
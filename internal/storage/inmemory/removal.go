@@ -0,0 +1,109 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+func (s *Storage) RemovePost(ctx context.Context, input *domain.RemoveInput, entry *domain.AddModLogEntryInput) (*domain.Post, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	post, ok := s.posts[input.ID]
+	if !ok {
+		return nil, errs.PostNotFound
+	}
+	before := domain.PostState(post)
+	post.Removal = newRemoval(input)
+	if err := s.addModLogEntry(entry, before, domain.PostState(post)); err != nil {
+		return nil, err
+	}
+
+	postCopy := *post
+	return &postCopy, nil
+}
+
+func (s *Storage) ApprovePost(ctx context.Context, id int, entry *domain.AddModLogEntryInput) (*domain.Post, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	post, ok := s.posts[id]
+	if !ok {
+		return nil, errs.PostNotFound
+	}
+	if !post.Removed() {
+		return nil, errs.NotRemoved
+	}
+	before := domain.PostState(post)
+	post.Removal = domain.Removal{}
+	if err := s.addModLogEntry(entry, before, domain.PostState(post)); err != nil {
+		return nil, err
+	}
+
+	postCopy := *post
+	return &postCopy, nil
+}
+
+func (s *Storage) RemoveComment(ctx context.Context, input *domain.RemoveInput, entry *domain.AddModLogEntryInput) (*domain.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, ok := s.comments[input.ID]
+	if !ok {
+		return nil, errs.CommentNotFound
+	}
+	if comment.Deleted {
+		return nil, errs.CommentDeleted
+	}
+
+	before := domain.CommentState(comment)
+	// Removed comments are not counted, same as deleted ones
+	if post, ok := s.posts[comment.PostID]; ok && !comment.Removed() {
+		post.CommentsCount--
+	}
+	comment.Removal = newRemoval(input)
+	if err := s.addModLogEntry(entry, before, domain.CommentState(comment)); err != nil {
+		return nil, err
+	}
+
+	commentCopy := *comment
+	return &commentCopy, nil
+}
+
+func (s *Storage) ApproveComment(ctx context.Context, id int, entry *domain.AddModLogEntryInput) (*domain.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, ok := s.comments[id]
+	if !ok {
+		return nil, errs.CommentNotFound
+	}
+	if !comment.Removed() {
+		return nil, errs.NotRemoved
+	}
+
+	before := domain.CommentState(comment)
+	if post, ok := s.posts[comment.PostID]; ok && !comment.Deleted {
+		post.CommentsCount++
+	}
+	comment.Removal = domain.Removal{}
+	if err := s.addModLogEntry(entry, before, domain.CommentState(comment)); err != nil {
+		return nil, err
+	}
+
+	commentCopy := *comment
+	return &commentCopy, nil
+}
+
+func newRemoval(input *domain.RemoveInput) domain.Removal {
+	moderatorID := input.ModeratorID
+	now := time.Now().UTC()
+	return domain.Removal{
+		RemovedBy:     &moderatorID,
+		RemovedReason: input.Reason,
+		RemovedAt:     &now,
+	}
+}
@@ -0,0 +1,115 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+func (s *Storage) CreateReport(ctx context.Context, input *domain.CreateReportInput) (*domain.Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.reports {
+		if r.ReporterID == input.ReporterID && sameTarget(r, input.PostID, input.CommentID) {
+			reportCopy := *r
+			return &reportCopy, nil
+		}
+	}
+
+	report := &domain.Report{
+		ID:         s.nextReportID,
+		PostID:     input.PostID,
+		CommentID:  input.CommentID,
+		Community:  input.Community,
+		ReporterID: input.ReporterID,
+		Reason:     input.Reason,
+		Text:       input.Text,
+		Status:     domain.ReportStatusOpen,
+		CreatedAt:  time.Now().UTC(),
+	}
+	s.reports[report.ID] = report
+	s.nextReportID++
+
+	reportCopy := *report
+	return &reportCopy, nil
+}
+
+func (s *Storage) GetReport(ctx context.Context, id int) (*domain.Report, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	report, ok := s.reports[id]
+	if !ok {
+		return nil, errs.ReportNotFound
+	}
+	reportCopy := *report
+	return &reportCopy, nil
+}
+
+func (s *Storage) ResolveReport(ctx context.Context, input *domain.ResolveReportInput, entry *domain.AddModLogEntryInput) (*domain.Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report, ok := s.reports[input.ID]
+	if !ok {
+		return nil, errs.ReportNotFound
+	}
+	if report.Status != domain.ReportStatusOpen {
+		return nil, errs.ReportResolved
+	}
+
+	now := time.Now().UTC()
+	for _, r := range s.reports {
+		if r.Status == domain.ReportStatusOpen && sameTarget(r, report.PostID, report.CommentID) {
+			r.Status = input.Status
+			r.ResolvedBy = &input.ResolverID
+			r.ResolvedAt = &now
+		}
+	}
+	before := &domain.ReportState{ReportID: report.ID, Status: domain.ReportStatusOpen}
+	after := &domain.ReportState{ReportID: report.ID, Status: report.Status}
+	if err := s.addModLogEntry(entry, before, after); err != nil {
+		return nil, err
+	}
+
+	reportCopy := *report
+	return &reportCopy, nil
+}
+
+func (s *Storage) GetReports(ctx context.Context, community string, status domain.ReportStatus, limit int32, afterID *int) (*domain.ReportsPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var reports []*domain.Report
+	for _, r := range s.reports {
+		if r.Community != community || r.Status != status {
+			continue
+		}
+		if afterID != nil && r.ID <= *afterID {
+			continue
+		}
+		reportCopy := *r
+		reports = append(reports, &reportCopy)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].ID < reports[j].ID
+	})
+
+	hasNext := len(reports) > int(limit)
+	if hasNext {
+		reports = reports[:limit]
+	}
+	return &domain.ReportsPage{Reports: reports, HasNext: hasNext}, nil
+}
+
+// sameTarget reports whether r flags given post or comment.
+func sameTarget(r *domain.Report, postID int, commentID *int) bool {
+	if commentID != nil {
+		return r.CommentID != nil && *r.CommentID == *commentID
+	}
+	return r.CommentID == nil && r.PostID == postID
+}
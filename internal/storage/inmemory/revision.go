@@ -0,0 +1,31 @@
+package inmemory
+
+import (
+	"context"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func (s *Storage) GetPostRevisions(ctx context.Context, postID int) ([]*domain.PostRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	revisions := make([]*domain.PostRevision, len(s.postRevisions[postID]))
+	for i, r := range s.postRevisions[postID] {
+		revisionCopy := *r
+		revisions[i] = &revisionCopy
+	}
+	return revisions, nil
+}
+
+func (s *Storage) GetCommentRevisions(ctx context.Context, commentID int) ([]*domain.CommentRevision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	revisions := make([]*domain.CommentRevision, len(s.commentRevisions[commentID]))
+	for i, r := range s.commentRevisions[commentID] {
+		revisionCopy := *r
+		revisions[i] = &revisionCopy
+	}
+	return revisions, nil
+}
@@ -0,0 +1,69 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+func (s *Storage) AddModerator(ctx context.Context, community string, userID uuid.UUID, entry *domain.AddModLogEntryInput) (*domain.Moderator, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mods, ok := s.moderators[community]
+	if !ok {
+		mods = make(map[uuid.UUID]*domain.Moderator)
+		s.moderators[community] = mods
+	}
+
+	mod, ok := mods[userID]
+	if !ok {
+		mod = &domain.Moderator{Community: community, UserID: userID, CreatedAt: time.Now().UTC()}
+		mods[userID] = mod
+		err := s.addModLogEntry(entry, &domain.ModeratorState{Moderator: false}, &domain.ModeratorState{Moderator: true})
+		if err != nil {
+			return nil, err
+		}
+	}
+	modCopy := *mod
+	return &modCopy, nil
+}
+
+func (s *Storage) RemoveModerator(ctx context.Context, community string, userID uuid.UUID, entry *domain.AddModLogEntryInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.moderators[community][userID]; !ok {
+		return errs.ModeratorNotFound
+	}
+	delete(s.moderators[community], userID)
+	return s.addModLogEntry(entry, &domain.ModeratorState{Moderator: true}, &domain.ModeratorState{Moderator: false})
+}
+
+func (s *Storage) IsModerator(ctx context.Context, community string, userID uuid.UUID) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.moderators[community][userID]
+	return ok, nil
+}
+
+func (s *Storage) GetModerators(ctx context.Context, community string) ([]*domain.Moderator, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	mods := make([]*domain.Moderator, 0, len(s.moderators[community]))
+	for _, mod := range s.moderators[community] {
+		modCopy := *mod
+		mods = append(mods, &modCopy)
+	}
+	sort.Slice(mods, func(i, j int) bool {
+		return mods[i].CreatedAt.Before(mods[j].CreatedAt)
+	})
+	return mods, nil
+}
@@ -0,0 +1,110 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+// savedKey identifies items of one type saved by user.
+type savedKey struct {
+	userID   uuid.UUID
+	itemType domain.SavedType
+}
+
+func (s *Storage) SaveItem(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, itemID int) (*domain.SavedItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := savedKey{userID: userID, itemType: itemType}
+	items, ok := s.saved[key]
+	if !ok {
+		items = make(map[int]*domain.SavedItem)
+		s.saved[key] = items
+	}
+
+	item, ok := items[itemID]
+	if !ok {
+		item = &domain.SavedItem{
+			UserID:  userID,
+			Type:    itemType,
+			ItemID:  itemID,
+			SavedAt: time.Now().UTC(),
+		}
+		items[itemID] = item
+	}
+
+	itemCopy := *item
+	return &itemCopy, nil
+}
+
+func (s *Storage) UnsaveItem(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, itemID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.saved[savedKey{userID: userID, itemType: itemType}], itemID)
+	return nil
+}
+
+func (s *Storage) GetSavedIDs(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, itemIDs []int) (map[int]bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := s.saved[savedKey{userID: userID, itemType: itemType}]
+	saved := make(map[int]bool)
+	for _, id := range itemIDs {
+		if _, ok := items[id]; ok {
+			saved[id] = true
+		}
+	}
+	return saved, nil
+}
+
+func (s *Storage) GetSavedItems(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, limit int32, cursor *domain.PostTimeCursor) (*domain.SavedPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []*domain.SavedItem
+	for _, item := range s.saved[savedKey{userID: userID, itemType: itemType}] {
+		if cursor != nil && !(item.SavedAt.Before(cursor.Time) || item.SavedAt.Equal(cursor.Time) && item.ItemID > cursor.ID) {
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		return a.SavedAt.After(b.SavedAt) || a.SavedAt.Equal(b.SavedAt) && a.ItemID < b.ItemID
+	})
+
+	page := &domain.SavedPage{Items: make([]*domain.SavedItem, 0, limit)}
+	for _, item := range items {
+		if len(page.Items) == int(limit) {
+			page.HasNext = true
+			break
+		}
+		itemCopy := *item
+		page.Items = append(page.Items, &itemCopy)
+	}
+	return page, nil
+}
+
+// unsavePurged drops saved items whose posts or comments were purged, same as cascade in database.
+func (s *Storage) unsavePurged() {
+	for key, items := range s.saved {
+		for id := range items {
+			var exists bool
+			if key.itemType == domain.SavedTypePost {
+				_, exists = s.posts[id]
+			} else {
+				_, exists = s.comments[id]
+			}
+			if !exists {
+				delete(items, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package inmemory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+// Snippet takes a few words before the first match and the rest up to snippetWords.
+const (
+	snippetLead  = 5
+	snippetWords = 30
+)
+
+// field is part of document, words of heavier fields rank higher.
+type field struct {
+	text   string
+	weight float64
+}
+
+// textIndex is inverted index of documents identified by id, it is guarded by Storage mutex.
+type textIndex struct {
+	postings map[string]map[int]float64 // Word -> document id -> weighted number of occurrences
+	words    map[int][]string           // Document id -> its distinct words, to unindex it
+}
+
+func newTextIndex() *textIndex {
+	return &textIndex{
+		postings: make(map[string]map[int]float64),
+		words:    make(map[int][]string),
+	}
+}
+
+// set replaces indexed content of document.
+func (idx *textIndex) set(id int, fields ...field) {
+	idx.remove(id)
+
+	freq := make(map[string]float64)
+	for _, f := range fields {
+		for _, w := range strings.FieldsFunc(strings.ToLower(f.text), notWordRune) {
+			freq[w] += f.weight
+		}
+	}
+
+	words := make([]string, 0, len(freq))
+	for w, n := range freq {
+		docs, ok := idx.postings[w]
+		if !ok {
+			docs = make(map[int]float64)
+			idx.postings[w] = docs
+		}
+		docs[id] = n
+		words = append(words, w)
+	}
+	idx.words[id] = words
+}
+
+func (idx *textIndex) remove(id int) {
+	for _, w := range idx.words[id] {
+		delete(idx.postings[w], id)
+		if len(idx.postings[w]) == 0 {
+			delete(idx.postings, w)
+		}
+	}
+	delete(idx.words, id)
+}
+
+// match ranks documents containing every word by tf-idf, nil words match nothing.
+func (idx *textIndex) match(words []string) map[int]float64 {
+	if len(words) == 0 {
+		return nil
+	}
+
+	total := float64(len(idx.words))
+	var ranks map[int]float64
+	for _, w := range words {
+		docs := idx.postings[w]
+		idf := math.Log(1 + total/float64(len(docs)))
+
+		next := make(map[int]float64)
+		for id, n := range docs {
+			if ranks != nil {
+				if _, ok := ranks[id]; !ok {
+					continue
+				}
+			}
+			next[id] = ranks[id] + (1+math.Log(n))*idf
+		}
+		ranks = next
+		if len(ranks) == 0 {
+			return nil
+		}
+	}
+	return ranks
+}
+
+// tokenize splits text into distinct lowercase words in order of appearance.
+func tokenize(text string) []string {
+	seen := make(map[string]struct{})
+	var words []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), notWordRune) {
+		if _, ok := seen[w]; !ok {
+			seen[w] = struct{}{}
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+func notWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// stripMatchMarks removes match marks from text, so that only matched words end up marked.
+var stripMatchMarks = strings.NewReplacer(domain.SnippetMatchStart, "", domain.SnippetMatchStop, "")
+
+// snippet returns fragment of text starting shortly before the first matched word, matched words are marked.
+func snippet(text string, words []string) string {
+	text = stripMatchMarks.Replace(text)
+	match := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		match[w] = struct{}{}
+	}
+
+	// Byte offsets of every word in text
+	type span struct{ start, end int }
+	var spans []span
+	start := -1
+	for i, r := range text {
+		if notWordRune(r) {
+			if start >= 0 {
+				spans = append(spans, span{start, i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, span{start, len(text)})
+	}
+
+	first := 0
+	for i, sp := range spans {
+		if _, ok := match[strings.ToLower(text[sp.start:sp.end])]; ok {
+			first = max(0, i-snippetLead)
+			break
+		}
+	}
+	spans = spans[first:min(len(spans), first+snippetWords)]
+	if len(spans) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	pos := spans[0].start
+	for _, sp := range spans {
+		b.WriteString(text[pos:sp.start])
+		word := text[sp.start:sp.end]
+		if _, ok := match[strings.ToLower(word)]; ok {
+			b.WriteString(domain.SnippetMatchStart + word + domain.SnippetMatchStop)
+		} else {
+			b.WriteString(word)
+		}
+		pos = sp.end
+	}
+	return b.String()
+}
+
+func (s *Storage) SearchPosts(ctx context.Context, q *domain.SearchQuery) (*domain.SearchPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	words := tokenize(q.Text)
+	var hits []*domain.SearchHit
+	for id, rank := range s.postIndex.match(words) {
+		post := s.posts[id]
+		if post.Deleted || post.Removed() || !matchFilter(&q.Filter, post.AuthorID, post.Community, post.CreatedAt, post.Rating) {
+			continue
+		}
+		postCopy := *post
+		hits = append(hits, &domain.SearchHit{Post: &postCopy, Rank: rank})
+	}
+
+	page := searchPage(hits, q)
+	for _, h := range page.Hits {
+		h.Snippet = snippet(h.Post.Title+" "+h.Post.Content, words)
+	}
+	return page, nil
+}
+
+func (s *Storage) SearchComments(ctx context.Context, q *domain.SearchQuery) (*domain.SearchPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	words := tokenize(q.Text)
+	var hits []*domain.SearchHit
+	for id, rank := range s.commentIndex.match(words) {
+		comment := s.comments[id]
+		post := s.posts[comment.PostID]
+		if comment.Deleted || comment.Removed() || post.Deleted || post.Removed() ||
+			!matchFilter(&q.Filter, comment.AuthorID, post.Community, comment.CreatedAt, comment.Rating) {
+			continue
+		}
+		commentCopy := *comment
+		hits = append(hits, &domain.SearchHit{Comment: &commentCopy, Rank: rank})
+	}
+
+	page := searchPage(hits, q)
+	for _, h := range page.Hits {
+		h.Snippet = snippet(*h.Comment.Text, words)
+	}
+	return page, nil
+}
+
+func matchFilter(f *domain.SearchFilter, authorID uuid.UUID, community string, createdAt time.Time, rating int32) bool {
+	return (f.AuthorID == nil || *f.AuthorID == authorID) &&
+		(f.Community == nil || *f.Community == community) &&
+		(f.Before == nil || createdAt.Before(*f.Before)) &&
+		(f.After == nil || !createdAt.Before(*f.After)) &&
+		(f.MinRating == nil || rating >= *f.MinRating)
+}
+
+// searchPage sorts hits in order of query and takes page after its cursor.
+func searchPage(hits []*domain.SearchHit, q *domain.SearchQuery) *domain.SearchPage {
+	type key struct {
+		id        int
+		createdAt int64
+		rating    int32
+	}
+	keyOf := func(h *domain.SearchHit) key {
+		if h.Comment != nil {
+			return key{h.Comment.ID, h.Comment.CreatedAt.UnixNano(), h.Comment.Rating}
+		}
+		return key{h.Post.ID, h.Post.CreatedAt.UnixNano(), h.Post.Rating}
+	}
+
+	// Every order breaks ties by id ascending
+	var less func(a, b *domain.SearchHit) bool
+	afterCursor := func(h *domain.SearchHit) bool { return true }
+	switch q.Sort {
+	case domain.SearchSortNew:
+		less = func(a, b *domain.SearchHit) bool {
+			ka, kb := keyOf(a), keyOf(b)
+			return ka.createdAt > kb.createdAt || ka.createdAt == kb.createdAt && ka.id < kb.id
+		}
+		if c := q.TimeCursor; c != nil {
+			t := c.Time.UnixNano()
+			afterCursor = func(h *domain.SearchHit) bool {
+				k := keyOf(h)
+				return k.createdAt < t || k.createdAt == t && k.id > c.ID
+			}
+		}
+	case domain.SearchSortRating:
+		less = func(a, b *domain.SearchHit) bool {
+			ka, kb := keyOf(a), keyOf(b)
+			return ka.rating > kb.rating || ka.rating == kb.rating && ka.id < kb.id
+		}
+		if c := q.RatingCursor; c != nil {
+			afterCursor = func(h *domain.SearchHit) bool {
+				k := keyOf(h)
+				return k.rating < c.Rating || k.rating == c.Rating && k.id > c.ID
+			}
+		}
+	default:
+		less = func(a, b *domain.SearchHit) bool {
+			return a.Rank > b.Rank || a.Rank == b.Rank && keyOf(a).id < keyOf(b).id
+		}
+		if c := q.RankCursor; c != nil {
+			afterCursor = func(h *domain.SearchHit) bool {
+				return h.Rank < c.Rank || h.Rank == c.Rank && keyOf(h).id > c.ID
+			}
+		}
+	}
+
+	page := &domain.SearchPage{Hits: make([]*domain.SearchHit, 0, q.Limit)}
+	sort.Slice(hits, func(i, j int) bool { return less(hits[i], hits[j]) })
+	for _, h := range hits {
+		if !afterCursor(h) {
+			continue
+		}
+		if len(page.Hits) == int(q.Limit) {
+			page.HasNext = true
+			break
+		}
+		page.Hits = append(page.Hits, h)
+	}
+	return page
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/ranking"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
 )
 
@@ -19,26 +20,55 @@ var _ storage.Storage = (*Storage)(nil)
 // Storage implements storage.Storage with an in-memory map.
 // It uses a sync.RWMutex to ensure concurrent access safety.
 type Storage struct {
-	posts        map[int]*domain.Post
-	comments     map[int]*domain.Comment
-	postVotes    map[int]map[uuid.UUID]*domain.PostVote    // PostID -> VoterID -> Vote
-	commentVotes map[int]map[uuid.UUID]*domain.CommentVote // CommentID -> VoterID -> Vote
+	posts            map[int]*domain.Post
+	comments         map[int]*domain.Comment
+	postVotes        map[int]map[uuid.UUID]*domain.PostVote     // PostID -> VoterID -> Vote
+	commentVotes     map[int]map[uuid.UUID]*domain.CommentVote  // CommentID -> VoterID -> Vote
+	buckets          map[string]*bucket                         // Rate limit key -> token bucket
+	moderators       map[string]map[uuid.UUID]*domain.Moderator // Community -> UserID -> Moderator
+	reports          map[int]*domain.Report
+	modLog           []*domain.ModLogEntry                // Ordered by id
+	bans             map[string]map[uuid.UUID]*domain.Ban // Community, empty for global -> UserID -> Ban
+	postIndex        *textIndex
+	commentIndex     *textIndex
+	saved            map[savedKey]map[int]*domain.SavedItem    // User and item type -> ItemID -> SavedItem
+	hiddenPosts      map[uuid.UUID]map[int]struct{}            // UserID -> PostID
+	blocks           map[uuid.UUID]map[uuid.UUID]*domain.Block // UserID -> BlockedID -> Block
+	postRevisions    map[int][]*domain.PostRevision            // PostID -> revisions ordered by number
+	commentRevisions map[int][]*domain.CommentRevision         // CommentID -> revisions ordered by number
+	idempotencyKeys  map[idempotencyKey]*domain.IdempotencyKey
+	linkPreviews     map[string]*domain.LinkPreview // NormalizedURL -> LinkPreview
 
 	// Mutex for concurrent access
 	mu sync.RWMutex
 	// Simple auto-incrementing IDs
 	nextPostID    int
 	nextCommentID int
+	nextReportID  int
 }
 
 func New() *Storage {
 	return &Storage{
-		posts:         make(map[int]*domain.Post),
-		comments:      make(map[int]*domain.Comment),
-		postVotes:     make(map[int]map[uuid.UUID]*domain.PostVote),
-		commentVotes:  make(map[int]map[uuid.UUID]*domain.CommentVote),
-		nextPostID:    1,
-		nextCommentID: 1,
+		posts:            make(map[int]*domain.Post),
+		comments:         make(map[int]*domain.Comment),
+		postVotes:        make(map[int]map[uuid.UUID]*domain.PostVote),
+		commentVotes:     make(map[int]map[uuid.UUID]*domain.CommentVote),
+		buckets:          make(map[string]*bucket),
+		moderators:       make(map[string]map[uuid.UUID]*domain.Moderator),
+		reports:          make(map[int]*domain.Report),
+		bans:             make(map[string]map[uuid.UUID]*domain.Ban),
+		postIndex:        newTextIndex(),
+		commentIndex:     newTextIndex(),
+		saved:            make(map[savedKey]map[int]*domain.SavedItem),
+		hiddenPosts:      make(map[uuid.UUID]map[int]struct{}),
+		blocks:           make(map[uuid.UUID]map[uuid.UUID]*domain.Block),
+		postRevisions:    make(map[int][]*domain.PostRevision),
+		commentRevisions: make(map[int][]*domain.CommentRevision),
+		idempotencyKeys:  make(map[idempotencyKey]*domain.IdempotencyKey),
+		linkPreviews:     make(map[string]*domain.LinkPreview),
+		nextPostID:       1,
+		nextCommentID:    1,
+		nextReportID:     1,
 	}
 }
 
@@ -52,16 +82,23 @@ func (s *Storage) CreatePost(ctx context.Context, input *domain.CreatePostInput)
 	post := &domain.Post{
 		ID:                 s.nextPostID,
 		AuthorID:           input.AuthorID,
+		Community:          input.Community,
 		Title:              input.Title,
 		Content:            input.Content,
+		Kind:               input.Kind,
+		URL:                input.URL,
+		Domain:             input.Domain,
+		NormalizedURL:      input.NormalizedURL,
 		CreatedAt:          now,
 		Rating:             0,
 		CommentsCount:      0,
 		CommentsRestricted: false,
+		Version:            1,
 	}
 	s.posts[post.ID] = post
 	s.nextPostID++
 	s.postVotes[post.ID] = make(map[uuid.UUID]*domain.PostVote) // Initialize vote map
+	s.indexPost(post)
 	return post, nil
 }
 
@@ -86,33 +123,115 @@ func (s *Storage) UpdatePost(ctx context.Context, input *domain.UpdatePostInput)
 	if !ok {
 		return nil, errs.PostNotFound // Updated
 	}
+	if post.Deleted {
+		return nil, errs.PostDeleted
+	}
+	if post.Removed() {
+		return nil, errs.PostRemoved
+	}
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != post.Version {
+		return nil, errs.Conflict
+	}
 
+	title, content := post.Title, post.Content
 	if input.Title != nil {
-		post.Title = *input.Title
+		title = *input.Title
 	}
 	if input.Content != nil {
-		post.Content = *input.Content
+		content = *input.Content
+	}
+	if title == post.Title && content == post.Content {
+		postCopy := *post
+		return &postCopy, nil
 	}
 
+	now := time.Now().UTC()
+	revisions := s.postRevisions[post.ID]
+	s.postRevisions[post.ID] = append(revisions, &domain.PostRevision{
+		Number:   len(revisions) + 1,
+		Title:    post.Title,
+		Content:  post.Content,
+		EditorID: input.EditorID,
+		EditedAt: now,
+	})
+	post.Title, post.Content, post.EditedAt = title, content, &now
+	post.Version++
+	s.indexPost(post)
+
 	postCopy := *post
 	return &postCopy, nil
 }
 
-func (s *Storage) DeletePost(ctx context.Context, id int) error {
+func (s *Storage) DeletePost(ctx context.Context, id int, entry *domain.AddModLogEntryInput) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, ok := s.posts[id]; !ok {
+	post, ok := s.posts[id]
+	if !ok {
 		return errs.PostNotFound // Updated
 	}
-	delete(s.posts, id)
-	delete(s.postVotes, id)
-	// In a real system, you would also delete related comments and comment votes.
-	// For simplicity, we skip comment deletion logic here.
-	return nil
+	before := domain.PostState(post)
+	if !post.Deleted {
+		now := time.Now().UTC()
+		post.Deleted = true
+		post.DeletedAt = &now
+	}
+	return s.addModLogEntry(entry, before, domain.PostState(post))
 }
 
-func (s *Storage) SetCommentsRestricted(ctx context.Context, id int, restricted bool) (*domain.Post, error) {
+func (s *Storage) RestorePost(ctx context.Context, id int, entry *domain.AddModLogEntryInput) (*domain.Post, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	post, ok := s.posts[id]
+	if !ok {
+		return nil, errs.PostNotFound
+	}
+	if !post.Deleted {
+		return nil, errs.NotDeleted
+	}
+	before := domain.PostState(post)
+	post.Deleted = false
+	post.DeletedAt = nil
+	if err := s.addModLogEntry(entry, before, domain.PostState(post)); err != nil {
+		return nil, err
+	}
+
+	postCopy := *post
+	return &postCopy, nil
+}
+
+func (s *Storage) PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+	for id, post := range s.posts {
+		if !post.Deleted || !post.DeletedAt.Before(deletedBefore) {
+			continue
+		}
+		delete(s.posts, id)
+		delete(s.postVotes, id)
+		delete(s.postRevisions, id)
+		s.postIndex.remove(id)
+		purged++
+	}
+
+	// Comments of purged posts go away with them, same as cascade in database
+	for id, comment := range s.comments {
+		if _, ok := s.posts[comment.PostID]; !ok {
+			delete(s.comments, id)
+			delete(s.commentVotes, id)
+			delete(s.commentRevisions, id)
+			s.commentIndex.remove(id)
+		}
+	}
+	s.unsavePurged()
+	s.unhidePurged()
+	return purged, nil
+}
+
+func (s *Storage) SetCommentsRestricted(ctx context.Context, id int, restricted bool, entry *domain.AddModLogEntryInput) (*domain.Post, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -120,7 +239,11 @@ func (s *Storage) SetCommentsRestricted(ctx context.Context, id int, restricted
 	if !ok {
 		return nil, errs.PostNotFound // Updated
 	}
+	before := domain.PostState(post)
 	post.CommentsRestricted = restricted
+	if err := s.addModLogEntry(entry, before, domain.PostState(post)); err != nil {
+		return nil, err
+	}
 	postCopy := *post
 	return &postCopy, nil
 }
@@ -133,6 +256,12 @@ func (s *Storage) VotePost(ctx context.Context, vote *domain.PostVote) (*domain.
 	if !ok {
 		return nil, errs.PostNotFound // Updated
 	}
+	if post.Deleted {
+		return nil, errs.PostDeleted
+	}
+	if post.Removed() {
+		return nil, errs.PostRemoved
+	}
 
 	votesMap := s.postVotes[vote.ID]
 
@@ -141,6 +270,7 @@ func (s *Storage) VotePost(ctx context.Context, vote *domain.PostVote) (*domain.
 	ratingChange := int32(vote.Value)
 
 	if exists {
+		post.Upvotes, post.Downvotes = withoutVote(post.Upvotes, post.Downvotes, currentVote.Value)
 		// If the voter is trying to submit the same vote again (e.g., upvote when already upvoted)
 		if currentVote.Value == vote.Value {
 			// Unvote: delete the vote and reduce rating by the value (1 or -1)
@@ -152,10 +282,12 @@ func (s *Storage) VotePost(ctx context.Context, vote *domain.PostVote) (*domain.
 			currentVote.Value = vote.Value
 			// Re-assign vote in map to be safe
 			votesMap[vote.VoterID] = currentVote
+			post.Upvotes, post.Downvotes = withVote(post.Upvotes, post.Downvotes, vote.Value)
 		}
 	} else {
 		// New vote: add the vote
 		votesMap[vote.VoterID] = vote
+		post.Upvotes, post.Downvotes = withVote(post.Upvotes, post.Downvotes, vote.Value)
 	}
 
 	post.Rating += ratingChange
@@ -163,98 +295,146 @@ func (s *Storage) VotePost(ctx context.Context, vote *domain.PostVote) (*domain.
 	return &postCopy, nil
 }
 
-func (s *Storage) GetPostsSortedByRating(ctx context.Context, limit int32, cursor *domain.PostRatingCursor) (*domain.PostsPage, error) {
+func (s *Storage) GetPostsByIDs(ctx context.Context, ids []int) ([]*domain.Post, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	postsSlice := make([]*domain.Post, 0, len(s.posts))
-	for _, post := range s.posts {
-		postsSlice = append(postsSlice, post)
+	posts := make([]*domain.Post, 0, len(ids))
+	for _, id := range ids {
+		if post, ok := s.posts[id]; ok {
+			postCopy := *post
+			posts = append(posts, &postCopy)
+		}
 	}
+	return posts, nil
+}
 
-	// Sort by Rating (descending), then by ID (descending) as tie-breaker
-	sort.Slice(postsSlice, func(i, j int) bool {
-		if postsSlice[i].Rating != postsSlice[j].Rating {
-			return postsSlice[i].Rating > postsSlice[j].Rating
-		}
-		return postsSlice[i].ID > postsSlice[j].ID
-	})
+func (s *Storage) GetPostVotes(ctx context.Context, voterID uuid.UUID, postIDs []int) (map[int]int8, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	startIndex := 0
-	if cursor != nil {
-		// Find the index of the post that comes *after* the cursor
-		for i, post := range postsSlice {
-			if post.Rating == cursor.Rating && post.ID == cursor.ID {
-				startIndex = i + 1
-				break
-			}
+	votes := make(map[int]int8, len(postIDs))
+	for _, id := range postIDs {
+		if vote, ok := s.postVotes[id][voterID]; ok {
+			votes[id] = vote.Value
 		}
 	}
+	return votes, nil
+}
+
+func (s *Storage) GetPostsSortedByRating(ctx context.Context, viewerID *uuid.UUID, filter domain.PostsFilter, limit int32, cursor *domain.PostRatingCursor) (*domain.PostsPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	// Apply limit
-	endIndex := startIndex + int(limit)
-	if endIndex > len(postsSlice) {
-		endIndex = len(postsSlice)
+	// Sort by Rating (descending), then by ID (ascending) as tie-breaker
+	less := func(a, b *domain.Post) bool {
+		if a.Rating != b.Rating {
+			return a.Rating > b.Rating
+		}
+		return a.ID < b.ID
 	}
 
-	pagePosts := postsSlice[startIndex:endIndex]
-	hasNext := endIndex < len(postsSlice)
+	var afterCursor func(p *domain.Post) bool
+	if cursor != nil {
+		afterCursor = func(p *domain.Post) bool {
+			return p.Rating < cursor.Rating || (p.Rating == cursor.Rating && p.ID > cursor.ID)
+		}
+	}
 
-	return &domain.PostsPage{
-		Posts:   pagePosts,
-		HasNext: hasNext,
-	}, nil
+	return s.postsPage(viewerID, filter, limit, less, afterCursor), nil
 }
 
-func (s *Storage) GetPostsSortedByTime(ctx context.Context, limit int32, cursor *domain.PostTimeCursor, newFirst bool) (*domain.PostsPage, error) {
+func (s *Storage) GetPostsSortedByTime(ctx context.Context, viewerID *uuid.UUID, filter domain.PostsFilter, limit int32, cursor *domain.PostTimeCursor, newFirst bool) (*domain.PostsPage, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	postsSlice := make([]*domain.Post, 0, len(s.posts))
-	for _, post := range s.posts {
-		postsSlice = append(postsSlice, post)
-	}
-
-	// Sort by CreatedAt, then by ID as tie-breaker
-	sort.Slice(postsSlice, func(i, j int) bool {
-		// For 'New' first, sort descending by time, then descending by ID.
-		if newFirst {
-			if !postsSlice[i].CreatedAt.Equal(postsSlice[j].CreatedAt) {
-				return postsSlice[i].CreatedAt.After(postsSlice[j].CreatedAt) // Newest first
-			}
-			return postsSlice[i].ID > postsSlice[j].ID // Tie-breaker
+	// Sort by CreatedAt, then by ID (ascending) as tie-breaker
+	less := func(a, b *domain.Post) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt) == newFirst
 		}
-		// For 'Old' first, sort ascending by time, then ascending by ID.
-		if !postsSlice[i].CreatedAt.Equal(postsSlice[j].CreatedAt) {
-			return postsSlice[i].CreatedAt.Before(postsSlice[j].CreatedAt) // Oldest first
-		}
-		return postsSlice[i].ID < postsSlice[j].ID // Tie-breaker
-	})
+		return a.ID < b.ID
+	}
 
-	startIndex := 0
+	var afterCursor func(p *domain.Post) bool
 	if cursor != nil {
-		// Find the index of the post that comes *after* the cursor
-		for i, post := range postsSlice {
-			if post.CreatedAt.Equal(cursor.Time) && post.ID == cursor.ID {
-				startIndex = i + 1
-				break
+		afterCursor = func(p *domain.Post) bool {
+			if p.CreatedAt.Equal(cursor.Time) {
+				return p.ID > cursor.ID
 			}
+			return p.CreatedAt.Before(cursor.Time) == newFirst
 		}
 	}
 
-	// Apply limit
-	endIndex := startIndex + int(limit)
-	if endIndex > len(postsSlice) {
-		endIndex = len(postsSlice)
+	return s.postsPage(viewerID, filter, limit, less, afterCursor), nil
+}
+
+// postsPage returns first limit posts not deleted, visible to viewer, matching filter and after cursor in order given by less.
+// Filtering by cursor instead of looking it up keeps pagination stable when post at cursor gets deleted.
+func (s *Storage) postsPage(viewerID *uuid.UUID, filter domain.PostsFilter, limit int32, less func(a, b *domain.Post) bool, afterCursor func(p *domain.Post) bool) *domain.PostsPage {
+	posts := make([]*domain.Post, 0)
+	for _, p := range s.posts {
+		if p.Deleted || s.hiddenFrom(viewerID, p) || !matchPostsFilter(&filter, p) {
+			continue
+		}
+		if afterCursor != nil && !afterCursor(p) {
+			continue
+		}
+		posts = append(posts, p)
 	}
 
-	pagePosts := postsSlice[startIndex:endIndex]
-	hasNext := endIndex < len(postsSlice)
+	sort.Slice(posts, func(i, j int) bool {
+		return less(posts[i], posts[j])
+	})
+
+	hasNext := len(posts) > int(limit)
+	if hasNext {
+		posts = posts[:limit]
+	}
 
 	return &domain.PostsPage{
-		Posts:   pagePosts,
+		Posts:   copyPosts(posts),
 		HasNext: hasNext,
-	}, nil
+	}
+}
+
+func matchPostsFilter(f *domain.PostsFilter, p *domain.Post) bool {
+	return f.Domain == nil || !p.Removed() && p.Domain != nil && *p.Domain == *f.Domain
+}
+
+func (s *Storage) GetRecentLinkPosts(ctx context.Context, community, normalizedURL string, since time.Time) ([]*domain.Post, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var posts []*domain.Post
+	for _, p := range s.posts {
+		if p.Community == community && p.NormalizedURL != nil && *p.NormalizedURL == normalizedURL &&
+			!p.Deleted && !p.Removed() && !p.CreatedAt.Before(since) {
+			posts = append(posts, p)
+		}
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		if !posts[i].CreatedAt.Equal(posts[j].CreatedAt) {
+			return posts[i].CreatedAt.After(posts[j].CreatedAt)
+		}
+		return posts[i].ID > posts[j].ID
+	})
+	return copyPosts(posts), nil
+}
+
+// copyPosts returns copies of posts to prevent external modification without lock.
+func copyPosts(posts []*domain.Post) []*domain.Post {
+	copies := make([]*domain.Post, len(posts))
+	for i, p := range posts {
+		postCopy := *p
+		copies[i] = &postCopy
+	}
+	return copies
+}
+
+// indexPost makes post searchable, title weighs more than content.
+func (s *Storage) indexPost(post *domain.Post) {
+	s.postIndex.set(post.ID, field{text: post.Title, weight: 2}, field{text: post.Content, weight: 1})
 }
 
 // --- Comment Methods ---
@@ -267,15 +447,18 @@ func (s *Storage) CreateComment(ctx context.Context, input *domain.CreateComment
 	if !ok {
 		return nil, errs.PostNotFound // Updated
 	}
+	if post.Deleted {
+		return nil, errs.PostDeleted
+	}
 	if post.CommentsRestricted {
-		return nil, fmt.Errorf("comments are restricted for post %d", input.PostID)
+		return nil, errs.CommentsRestricted
 	}
 
 	// Check parent comment if ParentID is set
 	if input.ParentID != nil {
 		parentComment, ok := s.comments[*input.ParentID]
 		if !ok {
-			return nil, errs.CommentNotFound // Parent comment not found (uses the generic CommentNotFound)
+			return nil, errs.ParentCommentNotFound
 		}
 		if parentComment.Text == nil {
 			return nil, errs.ReplyToDeletedComment // Updated
@@ -292,12 +475,14 @@ func (s *Storage) CreateComment(ctx context.Context, input *domain.CreateComment
 		CreatedAt: now,
 		Rating:    0,
 		ParentID:  input.ParentID,
+		Version:   1,
 	}
 
 	s.comments[comment.ID] = comment
 	s.nextCommentID++
 	s.commentVotes[comment.ID] = make(map[uuid.UUID]*domain.CommentVote) // Initialize vote map
 	post.CommentsCount++
+	s.commentIndex.set(comment.ID, field{text: text, weight: 1})
 
 	return comment, nil
 }
@@ -313,14 +498,36 @@ func (s *Storage) UpdateCommentIfNotDeleted(ctx context.Context, input *domain.U
 	if comment.Text == nil {
 		return nil, errs.CommentDeleted // Updated
 	}
+	if comment.Removed() {
+		return nil, errs.CommentRemoved
+	}
+
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != comment.Version {
+		return nil, errs.Conflict
+	}
+	if input.Text == *comment.Text {
+		commentCopy := *comment
+		return &commentCopy, nil
+	}
+
+	now := time.Now().UTC()
+	revisions := s.commentRevisions[comment.ID]
+	s.commentRevisions[comment.ID] = append(revisions, &domain.CommentRevision{
+		Number:   len(revisions) + 1,
+		Text:     *comment.Text,
+		EditorID: input.EditorID,
+		EditedAt: now,
+	})
 
 	newText := input.Text
-	comment.Text = &newText
+	comment.Text, comment.EditedAt = &newText, &now
+	comment.Version++
+	s.commentIndex.set(comment.ID, field{text: newText, weight: 1})
 	commentCopy := *comment
 	return &commentCopy, nil
 }
 
-func (s *Storage) DeleteComment(ctx context.Context, id int) error {
+func (s *Storage) DeleteComment(ctx context.Context, id int, entry *domain.AddModLogEntryInput) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -329,18 +536,21 @@ func (s *Storage) DeleteComment(ctx context.Context, id int) error {
 		return errs.CommentNotFound // Updated
 	}
 
+	before := domain.CommentState(comment)
 	if comment.Text != nil {
 		// "Delete" by setting Text to nil and removing content.
 		comment.Text = nil
+		comment.Deleted = true
+		s.commentIndex.remove(comment.ID)
 		// Recalculate post comments count (assuming deleted comments don't count)
-		if post, ok := s.posts[comment.PostID]; ok {
+		if post, ok := s.posts[comment.PostID]; ok && !comment.Removed() {
 			post.CommentsCount--
 		}
 	}
 
 	// In a real implementation, you might not delete comment votes,
 	// but keep them for historical purposes. For simplicity, we keep them here.
-	return nil
+	return s.addModLogEntry(entry, before, domain.CommentState(comment))
 }
 
 func (s *Storage) VoteCommentIfNotDeleted(ctx context.Context, vote *domain.CommentVote) (*domain.Comment, error) {
@@ -354,6 +564,9 @@ func (s *Storage) VoteCommentIfNotDeleted(ctx context.Context, vote *domain.Comm
 	if comment.Text == nil {
 		return nil, errs.CommentDeleted // Updated
 	}
+	if comment.Removed() {
+		return nil, errs.CommentRemoved
+	}
 
 	votesMap := s.commentVotes[vote.ID]
 
@@ -362,6 +575,7 @@ func (s *Storage) VoteCommentIfNotDeleted(ctx context.Context, vote *domain.Comm
 	ratingChange := int32(vote.Value)
 
 	if exists {
+		comment.Upvotes, comment.Downvotes = withoutVote(comment.Upvotes, comment.Downvotes, currentVote.Value)
 		if currentVote.Value == vote.Value {
 			// Unvote
 			delete(votesMap, vote.VoterID)
@@ -371,13 +585,16 @@ func (s *Storage) VoteCommentIfNotDeleted(ctx context.Context, vote *domain.Comm
 			ratingChange = int32(vote.Value) - int32(currentVote.Value)
 			currentVote.Value = vote.Value
 			votesMap[vote.VoterID] = currentVote
+			comment.Upvotes, comment.Downvotes = withVote(comment.Upvotes, comment.Downvotes, vote.Value)
 		}
 	} else {
 		// New vote
 		votesMap[vote.VoterID] = vote
+		comment.Upvotes, comment.Downvotes = withVote(comment.Upvotes, comment.Downvotes, vote.Value)
 	}
 
 	comment.Rating += ratingChange
+	comment.BestScore = ranking.Wilson(comment.Upvotes, comment.Downvotes)
 	commentCopy := *comment
 	return &commentCopy, nil
 }
@@ -394,4 +611,182 @@ func (s *Storage) GetComment(ctx context.Context, id int) (*domain.Comment, erro
 	return &commentCopy, nil
 }
 
+func (s *Storage) GetCommentsByIDs(ctx context.Context, ids []int) ([]*domain.Comment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	comments := make([]*domain.Comment, 0, len(ids))
+	for _, id := range ids {
+		if comment, ok := s.comments[id]; ok {
+			commentCopy := *comment
+			comments = append(comments, &commentCopy)
+		}
+	}
+	return comments, nil
+}
+
+func (s *Storage) GetCommentVotes(ctx context.Context, voterID uuid.UUID, commentIDs []int) (map[int]int8, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	votes := make(map[int]int8, len(commentIDs))
+	for _, id := range commentIDs {
+		if vote, ok := s.commentVotes[id][voterID]; ok {
+			votes[id] = vote.Value
+		}
+	}
+	return votes, nil
+}
+
+func (s *Storage) GetCommentsSortedByRating(ctx context.Context, viewerID *uuid.UUID, postID int, parentID *int, limit int32, cursor *domain.PostRatingCursor) (*domain.CommentsPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Sort by Rating (descending), then by ID (ascending) as tie-breaker
+	less := func(a, b *domain.Comment) bool {
+		if a.Rating != b.Rating {
+			return a.Rating > b.Rating
+		}
+		return a.ID < b.ID
+	}
+
+	var afterCursor func(c *domain.Comment) bool
+	if cursor != nil {
+		afterCursor = func(c *domain.Comment) bool {
+			return c.Rating < cursor.Rating || (c.Rating == cursor.Rating && c.ID > cursor.ID)
+		}
+	}
+
+	return s.commentsPage(viewerID, postID, parentID, limit, less, afterCursor), nil
+}
+
+func (s *Storage) GetCommentsSortedByTime(ctx context.Context, viewerID *uuid.UUID, postID int, parentID *int, limit int32, cursor *domain.PostTimeCursor, newFirst bool) (*domain.CommentsPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Sort by CreatedAt, then by ID (ascending) as tie-breaker
+	less := func(a, b *domain.Comment) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt) == newFirst
+		}
+		return a.ID < b.ID
+	}
+
+	var afterCursor func(c *domain.Comment) bool
+	if cursor != nil {
+		afterCursor = func(c *domain.Comment) bool {
+			if c.CreatedAt.Equal(cursor.Time) {
+				return c.ID > cursor.ID
+			}
+			return c.CreatedAt.Before(cursor.Time) == newFirst
+		}
+	}
+
+	return s.commentsPage(viewerID, postID, parentID, limit, less, afterCursor), nil
+}
+
+func (s *Storage) GetCommentsSortedByBest(ctx context.Context, viewerID *uuid.UUID, postID int, parentID *int, limit int32, cursor *domain.CommentBestCursor) (*domain.CommentsPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Sort by BestScore (descending), then by ID (ascending) as tie-breaker
+	less := func(a, b *domain.Comment) bool {
+		if a.BestScore != b.BestScore {
+			return a.BestScore > b.BestScore
+		}
+		return a.ID < b.ID
+	}
+
+	var afterCursor func(c *domain.Comment) bool
+	if cursor != nil {
+		afterCursor = func(c *domain.Comment) bool {
+			return c.BestScore < cursor.Score || (c.BestScore == cursor.Score && c.ID > cursor.ID)
+		}
+	}
+
+	return s.commentsPage(viewerID, postID, parentID, limit, less, afterCursor), nil
+}
+
+func (s *Storage) GetCommentsFirstPages(ctx context.Context, viewerID *uuid.UUID, parents []domain.CommentsParent, sort domain.SortOrder, limit int32) ([]*domain.CommentsPage, error) {
+	pages := make([]*domain.CommentsPage, len(parents))
+	for i, p := range parents {
+		var page *domain.CommentsPage
+		var err error
+		switch sort {
+		case domain.SortOrderRating:
+			page, err = s.GetCommentsSortedByRating(ctx, viewerID, p.PostID, p.ParentID, limit, nil)
+		case domain.SortOrderNew, domain.SortOrderOld:
+			page, err = s.GetCommentsSortedByTime(ctx, viewerID, p.PostID, p.ParentID, limit, nil, sort == domain.SortOrderNew)
+		case domain.SortOrderBest:
+			page, err = s.GetCommentsSortedByBest(ctx, viewerID, p.PostID, p.ParentID, limit, nil)
+		default:
+			err = fmt.Errorf("unknown sort order %q", sort)
+		}
+		if err != nil {
+			return nil, err
+		}
+		pages[i] = page
+	}
+	return pages, nil
+}
+
+// commentsPage selects siblings under parentID (top-level comments if nil) not written by users viewer blocked,
+// orders them with less and returns up to limit comments placed after cursor.
+// Caller must hold at least read lock.
+func (s *Storage) commentsPage(viewerID *uuid.UUID, postID int, parentID *int, limit int32, less func(a, b *domain.Comment) bool, afterCursor func(c *domain.Comment) bool) *domain.CommentsPage {
+	comments := make([]*domain.Comment, 0)
+	for _, c := range s.comments {
+		if c.PostID != postID || !sameParent(c.ParentID, parentID) || s.blockedBy(viewerID, c.AuthorID) {
+			continue
+		}
+		if afterCursor != nil && !afterCursor(c) {
+			continue
+		}
+		comments = append(comments, c)
+	}
+
+	sort.Slice(comments, func(i, j int) bool {
+		return less(comments[i], comments[j])
+	})
+
+	hasNext := len(comments) > int(limit)
+	if hasNext {
+		comments = comments[:limit]
+	}
+
+	page := make([]*domain.Comment, len(comments))
+	for i, c := range comments {
+		commentCopy := *c
+		page[i] = &commentCopy
+	}
+
+	return &domain.CommentsPage{
+		Comments: page,
+		HasNext:  hasNext,
+	}
+}
+
+func sameParent(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// withVote returns upvotes and downvotes counters after adding vote of given value.
+func withVote(upvotes, downvotes int32, value int8) (int32, int32) {
+	if value > 0 {
+		return upvotes + 1, downvotes
+	}
+	return upvotes, downvotes + 1
+}
+
+// withoutVote returns upvotes and downvotes counters after retracting vote of given value.
+func withoutVote(upvotes, downvotes int32, value int8) (int32, int32) {
+	if value > 0 {
+		return upvotes - 1, downvotes
+	}
+	return upvotes, downvotes - 1
+}
+
 func (s *Storage) Close() {}
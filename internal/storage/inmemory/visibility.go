@@ -0,0 +1,108 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func (s *Storage) HidePost(ctx context.Context, userID uuid.UUID, postID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hidden, ok := s.hiddenPosts[userID]
+	if !ok {
+		hidden = make(map[int]struct{})
+		s.hiddenPosts[userID] = hidden
+	}
+	hidden[postID] = struct{}{}
+	return nil
+}
+
+func (s *Storage) UnhidePost(ctx context.Context, userID uuid.UUID, postID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.hiddenPosts[userID], postID)
+	return nil
+}
+
+func (s *Storage) BlockUser(ctx context.Context, userID, blockedID uuid.UUID) (*domain.Block, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blocks, ok := s.blocks[userID]
+	if !ok {
+		blocks = make(map[uuid.UUID]*domain.Block)
+		s.blocks[userID] = blocks
+	}
+
+	block, ok := blocks[blockedID]
+	if !ok {
+		block = &domain.Block{
+			UserID:    userID,
+			BlockedID: blockedID,
+			CreatedAt: time.Now().UTC(),
+		}
+		blocks[blockedID] = block
+	}
+
+	blockCopy := *block
+	return &blockCopy, nil
+}
+
+func (s *Storage) UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.blocks[userID], blockedID)
+	return nil
+}
+
+func (s *Storage) GetBlocks(ctx context.Context, userID uuid.UUID) ([]*domain.Block, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	blocks := make([]*domain.Block, 0, len(s.blocks[userID]))
+	for _, b := range s.blocks[userID] {
+		blockCopy := *b
+		blocks = append(blocks, &blockCopy)
+	}
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].CreatedAt.After(blocks[j].CreatedAt)
+	})
+	return blocks, nil
+}
+
+// hiddenFrom reports whether viewer hid post or blocked its author. Caller must hold at least read lock.
+func (s *Storage) hiddenFrom(viewerID *uuid.UUID, p *domain.Post) bool {
+	if viewerID == nil {
+		return false
+	}
+	_, hidden := s.hiddenPosts[*viewerID][p.ID]
+	return hidden || s.blockedBy(viewerID, p.AuthorID)
+}
+
+// blockedBy reports whether viewer blocked user. Caller must hold at least read lock.
+func (s *Storage) blockedBy(viewerID *uuid.UUID, userID uuid.UUID) bool {
+	if viewerID == nil {
+		return false
+	}
+	_, blocked := s.blocks[*viewerID][userID]
+	return blocked
+}
+
+// unhidePurged drops hidden marks of purged posts, same as cascade in database.
+func (s *Storage) unhidePurged() {
+	for _, hidden := range s.hiddenPosts {
+		for id := range hidden {
+			if _, ok := s.posts[id]; !ok {
+				delete(hidden, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+func (s *Storage) BanUser(ctx context.Context, input *domain.BanInput, entry *domain.AddModLogEntryInput) (*domain.Ban, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := `SELECT * FROM bans
+		  WHERE community IS NOT DISTINCT FROM $1 AND user_id = $2
+		    AND (expires_at IS NULL OR expires_at > NOW())
+		  FOR UPDATE`
+	rows, _ := tx.Query(ctx, q, input.Community, input.UserID)
+	before, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Ban])
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	q = `INSERT INTO bans (community, user_id, reason, banned_by, expires_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (COALESCE(community, ''), user_id) DO UPDATE
+		 SET reason = EXCLUDED.reason, banned_by = EXCLUDED.banned_by,
+		     created_at = NOW(), expires_at = EXCLUDED.expires_at
+		 RETURNING *`
+	rows, _ = tx.Query(ctx, q, input.Community, input.UserID, input.Reason, input.BannedBy, input.ExpiresAt)
+	ban, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Ban])
+	if err != nil {
+		return nil, err
+	}
+	if err := addModLogEntry(ctx, tx, entry, domain.BanStateOf(before), domain.BanStateOf(ban)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+func (s *Storage) UnbanUser(ctx context.Context, community *string, userID uuid.UUID, entry *domain.AddModLogEntryInput) (*domain.Ban, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := `DELETE FROM bans
+		  WHERE community IS NOT DISTINCT FROM $1 AND user_id = $2
+		    AND (expires_at IS NULL OR expires_at > NOW())
+		  RETURNING *`
+	rows, _ := tx.Query(ctx, q, community, userID)
+	ban, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Ban])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.BanNotFound
+		}
+		return nil, err
+	}
+	if err := addModLogEntry(ctx, tx, entry, domain.BanStateOf(ban), domain.BanStateOf(nil)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+func (s *Storage) GetActiveBan(ctx context.Context, community string, userID uuid.UUID) (*domain.Ban, error) {
+	q := `SELECT * FROM bans
+		  WHERE user_id = $2 AND (community = $1 OR community IS NULL)
+		    AND (expires_at IS NULL OR expires_at > NOW())
+		  ORDER BY community NULLS LAST
+		  LIMIT 1`
+	rows, _ := s.pool.Query(ctx, q, community, userID)
+	ban, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Ban])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.BanNotFound
+		}
+		return nil, err
+	}
+	return ban, nil
+}
+
+func (s *Storage) GetActiveBans(ctx context.Context, community *string) ([]*domain.Ban, error) {
+	q := `SELECT * FROM bans
+		  WHERE community IS NOT DISTINCT FROM $1
+		    AND (expires_at IS NULL OR expires_at > NOW())
+		  ORDER BY created_at DESC`
+	rows, _ := s.pool.Query(ctx, q, community)
+	return pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Ban])
+}
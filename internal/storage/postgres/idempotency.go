@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func (s *Storage) ReserveIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) (*domain.IdempotencyKey, error) {
+	// Conflicting key is taken over only if it has expired, so that concurrent retries reserve key once
+	q := `INSERT INTO idempotency_keys AS k (user_id, operation, key, fingerprint, expires_at)
+		  VALUES ($1, $2, $3, $4, $5)
+		  ON CONFLICT (user_id, operation, key) DO UPDATE
+		  SET fingerprint = EXCLUDED.fingerprint,
+		      result_id   = NULL,
+		      created_at  = NOW(),
+		      expires_at  = EXCLUDED.expires_at
+		  WHERE k.expires_at <= NOW()`
+	tag, err := s.pool.Exec(ctx, q, key.UserID, key.Operation, key.Key, key.Fingerprint, key.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() > 0 {
+		return nil, nil
+	}
+
+	q = `SELECT * FROM idempotency_keys WHERE user_id = $1 AND operation = $2 AND key = $3`
+	rows, _ := s.pool.Query(ctx, q, key.UserID, key.Operation, key.Key)
+	existing, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.IdempotencyKey])
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Key was deleted in between, reserving again takes it
+		return s.ReserveIdempotencyKey(ctx, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, userID uuid.UUID, operation domain.IdempotencyOperation, key string, resultID int, expiresAt time.Time) error {
+	q := `UPDATE idempotency_keys
+		  SET result_id = $4, expires_at = $5
+		  WHERE user_id = $1 AND operation = $2 AND key = $3 AND result_id IS NULL`
+	_, err := s.pool.Exec(ctx, q, userID, operation, key, resultID, expiresAt)
+	return err
+}
+
+func (s *Storage) ReleaseIdempotencyKey(ctx context.Context, userID uuid.UUID, operation domain.IdempotencyOperation, key string) error {
+	q := `DELETE FROM idempotency_keys WHERE user_id = $1 AND operation = $2 AND key = $3`
+	_, err := s.pool.Exec(ctx, q, userID, operation, key)
+	return err
+}
+
+func (s *Storage) DeleteExpiredIdempotencyKeys(ctx context.Context) (int, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
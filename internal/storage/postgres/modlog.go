@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+// addModLogEntry appends entry with given states of its target within transaction of action it records,
+// nil entry is skipped.
+func addModLogEntry(ctx context.Context, tx pgx.Tx, entry *domain.AddModLogEntryInput, before, after any) error {
+	if entry == nil {
+		return nil
+	}
+
+	q := `INSERT INTO mod_log (community, actor_id, action, target_type, target_id, before, after)
+		  VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := tx.Exec(ctx, q, entry.Community, entry.ActorID, entry.Action, entry.TargetType, entry.TargetID, before, after)
+	return err
+}
+
+func (s *Storage) GetModLog(ctx context.Context, filter *domain.ModLogFilter, limit int32, beforeID *int) (*domain.ModLogPage, error) {
+	q := `SELECT * FROM mod_log
+		  WHERE community IS NOT DISTINCT FROM $1
+		    AND ($2::uuid IS NULL OR actor_id = $2)
+		    AND ($3::TEXT IS NULL OR action = $3)
+		    AND ($4::BIGINT IS NULL OR id < $4)
+		  ORDER BY id DESC
+		  LIMIT $5`
+	rows, _ := s.pool.Query(ctx, q, filter.Community, filter.ActorID, filter.Action, beforeID, limit+1)
+	entries, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.ModLogEntry])
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := len(entries) > int(limit)
+	if hasNext {
+		entries = entries[:limit]
+	}
+	return &domain.ModLogPage{Entries: entries, HasNext: hasNext}, nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func (s *Storage) SaveLinkPreview(ctx context.Context, preview *domain.LinkPreview) error {
+	q := `INSERT INTO link_previews (normalized_url, status, title, description, image_url, fetched_at)
+		  VALUES ($1, $2, $3, $4, $5, $6)
+		  ON CONFLICT (normalized_url) DO UPDATE
+		  SET status      = EXCLUDED.status,
+		      title       = EXCLUDED.title,
+		      description = EXCLUDED.description,
+		      image_url   = EXCLUDED.image_url,
+		      fetched_at  = EXCLUDED.fetched_at`
+	_, err := s.pool.Exec(ctx, q, preview.NormalizedURL, preview.Status, preview.Title, preview.Description, preview.ImageURL, preview.FetchedAt)
+	return err
+}
+
+type postPreview struct {
+	PostID int `db:"post_id"`
+	domain.LinkPreview
+}
+
+func (s *Storage) GetLinkPreviews(ctx context.Context, postIDs []int) (map[int]*domain.LinkPreview, error) {
+	q := `SELECT p.id AS post_id, l.*
+		  FROM posts p
+		  JOIN link_previews l ON l.normalized_url = p.normalized_url
+		  WHERE p.id = ANY($1)`
+	rows, _ := s.pool.Query(ctx, q, postIDs)
+	found, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[postPreview])
+	if err != nil {
+		return nil, err
+	}
+
+	previews := make(map[int]*domain.LinkPreview, len(found))
+	for _, f := range found {
+		previews[f.PostID] = &f.LinkPreview
+	}
+	return previews, nil
+}
+
+func (s *Storage) GetLinksToPreview(ctx context.Context, staleBefore time.Time, limit int) ([]*domain.Link, error) {
+	// Newest posts first, their links are the most likely to be viewed
+	q := `SELECT url, normalized_url
+		  FROM (SELECT DISTINCT ON (p.normalized_url) p.id, p.url, p.normalized_url
+		        FROM posts p
+		        LEFT JOIN link_previews l ON l.normalized_url = p.normalized_url
+		        WHERE p.normalized_url IS NOT NULL AND NOT p.deleted
+		          AND (l.normalized_url IS NULL OR (l.fetched_at < $1 AND l.fetched_at < p.created_at))
+		        ORDER BY p.normalized_url, p.id DESC) links
+		  ORDER BY id DESC
+		  LIMIT $2`
+	rows, _ := s.pool.Query(ctx, q, staleBefore, limit)
+	return pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Link])
+}
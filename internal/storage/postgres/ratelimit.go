@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func (s *Storage) TakeToken(ctx context.Context, key string, limit domain.RateLimit) (bool, time.Duration, error) {
+	interval := limit.Interval.Seconds()
+
+	// Refill and take token in single statement so that concurrent instances never overspend bucket
+	q := `INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at)
+		  VALUES ($1, $3 - 1, NOW())
+		  ON CONFLICT (key) DO UPDATE
+		  SET tokens     = LEAST($3, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) / $2) - 1,
+		      updated_at = NOW()
+		  WHERE LEAST($3, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) / $2) >= 1
+		  RETURNING tokens`
+	var tokens float64
+	err := s.pool.QueryRow(ctx, q, key, interval, float64(limit.Burst)).Scan(&tokens)
+	if err == nil {
+		return true, 0, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return false, 0, err
+	}
+
+	q = `SELECT LEAST($3, tokens + EXTRACT(EPOCH FROM NOW() - updated_at) / $2)
+		 FROM rate_limit_buckets
+		 WHERE key = $1`
+	err = s.pool.QueryRow(ctx, q, key, interval, float64(limit.Burst)).Scan(&tokens)
+	if err != nil {
+		return false, 0, err
+	}
+	return false, time.Duration((1 - tokens) * float64(limit.Interval)), nil
+}
+
+func (s *Storage) DeleteIdleBuckets(ctx context.Context, updatedBefore time.Time) (int, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM rate_limit_buckets WHERE updated_at < $1`, updatedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
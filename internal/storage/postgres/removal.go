@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+func (s *Storage) RemovePost(ctx context.Context, input *domain.RemoveInput, entry *domain.AddModLogEntryInput) (*domain.Post, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockPost(ctx, tx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `UPDATE posts
+		  SET removed_by = $2, removed_reason = $3, removed_at = NOW()
+		  WHERE id = $1
+		  RETURNING *`
+	rows, _ := tx.Query(ctx, q, input.ID, input.ModeratorID, input.Reason)
+	post, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Post])
+	if err != nil {
+		return nil, err
+	}
+	if err := addModLogEntry(ctx, tx, entry, domain.PostState(before), domain.PostState(post)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *Storage) ApprovePost(ctx context.Context, id int, entry *domain.AddModLogEntryInput) (*domain.Post, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockPost(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !before.Removed() {
+		return nil, errs.NotRemoved
+	}
+
+	q := `UPDATE posts
+		  SET removed_by = NULL, removed_reason = NULL, removed_at = NULL
+		  WHERE id = $1
+		  RETURNING *`
+	rows, _ := tx.Query(ctx, q, id)
+	post, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Post])
+	if err != nil {
+		return nil, err
+	}
+	if err := addModLogEntry(ctx, tx, entry, domain.PostState(before), domain.PostState(post)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *Storage) RemoveComment(ctx context.Context, input *domain.RemoveInput, entry *domain.AddModLogEntryInput) (*domain.Comment, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockComment(ctx, tx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if before.Deleted {
+		return nil, errs.CommentDeleted
+	}
+
+	q := `UPDATE comments
+		  SET removed_by = $2, removed_reason = $3, removed_at = NOW()
+		  WHERE id = $1
+		  RETURNING *`
+	rows, _ := tx.Query(ctx, q, input.ID, input.ModeratorID, input.Reason)
+	comment, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Comment])
+	if err != nil {
+		return nil, err
+	}
+	if err := addModLogEntry(ctx, tx, entry, domain.CommentState(before), domain.CommentState(comment)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *Storage) ApproveComment(ctx context.Context, id int, entry *domain.AddModLogEntryInput) (*domain.Comment, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockComment(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !before.Removed() {
+		return nil, errs.NotRemoved
+	}
+
+	q := `UPDATE comments
+		  SET removed_by = NULL, removed_reason = NULL, removed_at = NULL
+		  WHERE id = $1
+		  RETURNING *`
+	rows, _ := tx.Query(ctx, q, id)
+	comment, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Comment])
+	if err != nil {
+		return nil, err
+	}
+	if err := addModLogEntry(ctx, tx, entry, domain.CommentState(before), domain.CommentState(comment)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// lockPost returns post locked until the end of transaction.
+func lockPost(ctx context.Context, tx pgx.Tx, id int) (*domain.Post, error) {
+	q := `SELECT * FROM posts
+		  WHERE id = $1
+		  FOR UPDATE`
+	rows, _ := tx.Query(ctx, q, id)
+	post, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Post])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.PostNotFound
+		}
+		return nil, err
+	}
+	return post, nil
+}
+
+// lockComment returns comment locked until the end of transaction.
+func lockComment(ctx context.Context, tx pgx.Tx, id int) (*domain.Comment, error) {
+	q := `SELECT * FROM comments
+		  WHERE id = $1
+		  FOR UPDATE`
+	rows, _ := tx.Query(ctx, q, id)
+	comment, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Comment])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.CommentNotFound
+		}
+		return nil, err
+	}
+	return comment, nil
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+func (s *Storage) CreateReport(ctx context.Context, input *domain.CreateReportInput) (*domain.Report, error) {
+	q := `INSERT INTO reports (post_id, comment_id, community, reporter_id, reason, text)
+		  VALUES ($1, $2, $3, $4, $5, $6)
+		  ON CONFLICT DO NOTHING
+		  RETURNING *`
+	rows, _ := s.pool.Query(ctx, q, input.PostID, input.CommentID, input.Community, input.ReporterID, input.Reason, input.Text)
+	report, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Report])
+	if err == nil {
+		return report, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	// Reporter already reported this content
+	q = `SELECT * FROM reports
+		 WHERE reporter_id = $1 AND post_id = $2 AND comment_id IS NOT DISTINCT FROM $3`
+	rows, _ = s.pool.Query(ctx, q, input.ReporterID, input.PostID, input.CommentID)
+	return pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Report])
+}
+
+func (s *Storage) GetReport(ctx context.Context, id int) (*domain.Report, error) {
+	q := `SELECT * FROM reports
+		  WHERE id = $1`
+	rows, _ := s.pool.Query(ctx, q, id)
+	report, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Report])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ReportNotFound
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *Storage) ResolveReport(ctx context.Context, input *domain.ResolveReportInput, entry *domain.AddModLogEntryInput) (*domain.Report, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := `UPDATE reports r
+		  SET status = $2, resolved_by = $3, resolved_at = NOW()
+		  FROM reports target
+		  WHERE target.id = $1
+		    AND target.status = 'OPEN'
+		    AND r.status = 'OPEN'
+		    AND r.post_id = target.post_id
+		    AND r.comment_id IS NOT DISTINCT FROM target.comment_id
+		  RETURNING r.*`
+	rows, _ := tx.Query(ctx, q, input.ID, input.Status, input.ResolverID)
+	reports, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Report])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range reports {
+		if r.ID != input.ID {
+			continue
+		}
+		before := &domain.ReportState{ReportID: r.ID, Status: domain.ReportStatusOpen}
+		after := &domain.ReportState{ReportID: r.ID, Status: r.Status}
+		if err := addModLogEntry(ctx, tx, entry, before, after); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+
+	// Nothing updated, so report is either missing or not open
+	report, err := s.GetReport(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != domain.ReportStatusOpen {
+		return nil, errs.ReportResolved
+	}
+	return nil, errors.New("open report was not resolved")
+}
+
+func (s *Storage) GetReports(ctx context.Context, community string, status domain.ReportStatus, limit int32, afterID *int) (*domain.ReportsPage, error) {
+	q := `SELECT * FROM reports
+		  WHERE community = $1 AND status = $2 AND ($3::BIGINT IS NULL OR id > $3)
+		  ORDER BY id
+		  LIMIT $4`
+	rows, _ := s.pool.Query(ctx, q, community, status, afterID, limit+1)
+	reports, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Report])
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := len(reports) > int(limit)
+	if hasNext {
+		reports = reports[:limit]
+	}
+	return &domain.ReportsPage{Reports: reports, HasNext: hasNext}, nil
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+func (s *Storage) UpdatePost(ctx context.Context, input *domain.UpdatePostInput) (*domain.Post, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := `SELECT * FROM posts
+		  WHERE id = $1
+		  FOR UPDATE`
+	rows, _ := tx.Query(ctx, q, input.ID)
+	post, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Post])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.PostNotFound
+		}
+		return nil, err
+	}
+	if post.Deleted {
+		return nil, errs.PostDeleted
+	}
+	if post.Removed() {
+		return nil, errs.PostRemoved
+	}
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != post.Version {
+		return nil, errs.Conflict
+	}
+
+	title, content := post.Title, post.Content
+	if input.Title != nil {
+		title = *input.Title
+	}
+	if input.Content != nil {
+		content = *input.Content
+	}
+	if title == post.Title && content == post.Content {
+		return post, nil
+	}
+
+	q = `INSERT INTO post_revisions (post_id, title, content, editor_id)
+		 VALUES ($1, $2, $3, $4)`
+	if _, err := tx.Exec(ctx, q, post.ID, post.Title, post.Content, input.EditorID); err != nil {
+		return nil, err
+	}
+
+	q = `UPDATE posts
+		 SET title = $2, content = $3, edited_at = NOW(), version = version + 1
+		 WHERE id = $1
+		 RETURNING *`
+	rows, _ = tx.Query(ctx, q, post.ID, title, content)
+	post, err = pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Post])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *Storage) UpdateCommentIfNotDeleted(ctx context.Context, input *domain.UpdateCommentInput) (*domain.Comment, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := `SELECT * FROM comments
+		  WHERE id = $1
+		  FOR UPDATE`
+	rows, _ := tx.Query(ctx, q, input.ID)
+	comment, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Comment])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.CommentNotFound
+		}
+		return nil, err
+	}
+	if comment.Deleted {
+		return nil, errs.CommentDeleted
+	}
+	if comment.Removed() {
+		return nil, errs.CommentRemoved
+	}
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != comment.Version {
+		return nil, errs.Conflict
+	}
+	if input.Text == *comment.Text {
+		return comment, nil
+	}
+
+	q = `INSERT INTO comment_revisions (comment_id, "text", editor_id)
+		 VALUES ($1, $2, $3)`
+	if _, err := tx.Exec(ctx, q, comment.ID, *comment.Text, input.EditorID); err != nil {
+		return nil, err
+	}
+
+	q = `UPDATE comments
+		 SET "text" = $2, edited_at = NOW(), version = version + 1
+		 WHERE id = $1
+		 RETURNING *`
+	rows, _ = tx.Query(ctx, q, comment.ID, input.Text)
+	comment, err = pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Comment])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (s *Storage) GetPostRevisions(ctx context.Context, postID int) ([]*domain.PostRevision, error) {
+	q := `SELECT ROW_NUMBER() OVER (ORDER BY id)::INT AS number, title, content, editor_id, edited_at
+		  FROM post_revisions
+		  WHERE post_id = $1
+		  ORDER BY id`
+	rows, _ := s.pool.Query(ctx, q, postID)
+	return pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.PostRevision])
+}
+
+func (s *Storage) GetCommentRevisions(ctx context.Context, commentID int) ([]*domain.CommentRevision, error) {
+	q := `SELECT ROW_NUMBER() OVER (ORDER BY id)::INT AS number, "text", editor_id, edited_at
+		  FROM comment_revisions
+		  WHERE comment_id = $1
+		  ORDER BY id`
+	rows, _ := s.pool.Query(ctx, q, commentID)
+	return pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.CommentRevision])
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+func (s *Storage) AddModerator(ctx context.Context, community string, userID uuid.UUID, entry *domain.AddModLogEntryInput) (*domain.Moderator, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := `INSERT INTO community_moderators (community, user_id)
+		  VALUES ($1, $2)
+		  ON CONFLICT (community, user_id) DO NOTHING
+		  RETURNING community, user_id, created_at`
+	rows, _ := tx.Query(ctx, q, community, userID)
+	mod, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Moderator])
+	if errors.Is(err, pgx.ErrNoRows) {
+		// User already moderates community, nothing to log
+		q = `SELECT community, user_id, created_at FROM community_moderators
+			 WHERE community = $1 AND user_id = $2`
+		rows, _ = tx.Query(ctx, q, community, userID)
+		return pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Moderator])
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = addModLogEntry(ctx, tx, entry, &domain.ModeratorState{Moderator: false}, &domain.ModeratorState{Moderator: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return mod, nil
+}
+
+func (s *Storage) RemoveModerator(ctx context.Context, community string, userID uuid.UUID, entry *domain.AddModLogEntryInput) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	q := `DELETE FROM community_moderators
+		  WHERE community = $1 AND user_id = $2`
+	commandTag, err := tx.Exec(ctx, q, community, userID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return errs.ModeratorNotFound
+	}
+	err = addModLogEntry(ctx, tx, entry, &domain.ModeratorState{Moderator: true}, &domain.ModeratorState{Moderator: false})
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *Storage) IsModerator(ctx context.Context, community string, userID uuid.UUID) (bool, error) {
+	q := `SELECT EXISTS (SELECT 1 FROM community_moderators WHERE community = $1 AND user_id = $2)`
+	var ok bool
+	err := s.pool.QueryRow(ctx, q, community, userID).Scan(&ok)
+	return ok, err
+}
+
+func (s *Storage) GetModerators(ctx context.Context, community string) ([]*domain.Moderator, error) {
+	q := `SELECT community, user_id, created_at FROM community_moderators
+		  WHERE community = $1
+		  ORDER BY created_at, user_id`
+	rows, _ := s.pool.Query(ctx, q, community)
+	return pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Moderator])
+}
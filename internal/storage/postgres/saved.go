@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+// savedTable returns table of saved items of given type and column referencing item.
+func savedTable(itemType domain.SavedType) (string, string) {
+	if itemType == domain.SavedTypeComment {
+		return "saved_comments", "comment_id"
+	}
+	return "saved_posts", "post_id"
+}
+
+func (s *Storage) SaveItem(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, itemID int) (*domain.SavedItem, error) {
+	table, column := savedTable(itemType)
+	// No-op update makes conflicting row returned as well
+	q := fmt.Sprintf(`INSERT INTO %[1]s (user_id, %[2]s)
+		  VALUES ($1, $2)
+		  ON CONFLICT (user_id, %[2]s) DO UPDATE SET saved_at = %[1]s.saved_at
+		  RETURNING user_id, %[2]s AS item_id, saved_at`, table, column)
+	rows, _ := s.pool.Query(ctx, q, userID, itemID)
+	item, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.SavedItem])
+	if err != nil {
+		return nil, err
+	}
+	item.Type = itemType
+	return item, nil
+}
+
+func (s *Storage) UnsaveItem(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, itemID int) error {
+	table, column := savedTable(itemType)
+	q := fmt.Sprintf(`DELETE FROM %s WHERE user_id = $1 AND %s = $2`, table, column)
+	_, err := s.pool.Exec(ctx, q, userID, itemID)
+	return err
+}
+
+func (s *Storage) GetSavedIDs(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, itemIDs []int) (map[int]bool, error) {
+	table, column := savedTable(itemType)
+	q := fmt.Sprintf(`SELECT %[2]s FROM %[1]s WHERE user_id = $1 AND %[2]s = ANY($2)`, table, column)
+	rows, _ := s.pool.Query(ctx, q, userID, itemIDs)
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, err
+	}
+
+	saved := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		saved[id] = true
+	}
+	return saved, nil
+}
+
+func (s *Storage) GetSavedItems(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, limit int32, cursor *domain.PostTimeCursor) (*domain.SavedPage, error) {
+	table, column := savedTable(itemType)
+	args := []any{userID, limit + 1}
+	after := "TRUE"
+	if cursor != nil {
+		args = append(args, cursor.Time, cursor.ID)
+		after = fmt.Sprintf("saved_at < $3 OR (saved_at = $3 AND %s > $4)", column)
+	}
+	q := fmt.Sprintf(`SELECT user_id, %[2]s AS item_id, saved_at FROM %[1]s
+		  WHERE user_id = $1 AND (%[3]s)
+		  ORDER BY saved_at DESC, %[2]s ASC
+		  LIMIT $2`, table, column, after)
+
+	rows, _ := s.pool.Query(ctx, q, args...)
+	items, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.SavedItem])
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.SavedPage{Items: items, HasNext: len(items) > int(limit)}
+	if page.HasNext {
+		page.Items = items[:limit]
+	}
+	for _, item := range page.Items {
+		item.Type = itemType
+	}
+	return page, nil
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+var headlineOptions = fmt.Sprintf("StartSel=%s, StopSel=%s, MaxWords=35, MinWords=15, MaxFragments=2", domain.SnippetMatchStart, domain.SnippetMatchStop)
+
+type postHit struct {
+	domain.Post
+	Rank    float64 `db:"rank"`
+	Snippet string  `db:"snippet"`
+}
+
+type commentHit struct {
+	domain.Comment
+	Rank    float64 `db:"rank"`
+	Snippet string  `db:"snippet"`
+}
+
+func (s *Storage) SearchPosts(ctx context.Context, q *domain.SearchQuery) (*domain.SearchPage, error) {
+	matches := `SELECT p.*, ts_rank(d.document, query)::FLOAT8 AS rank
+				FROM posts p
+				JOIN post_documents d ON d.post_id = p.id,
+				     websearch_to_tsquery('english', $1) query
+				WHERE d.document @@ query AND NOT p.deleted AND p.removed_at IS NULL`
+	text := `h.title || ' ' || h.content`
+
+	sql, args := searchSQL(q, matches, postFilterColumns, text)
+	rows, _ := s.pool.Query(ctx, sql, args...)
+	hits, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[postHit])
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.SearchPage{Hits: make([]*domain.SearchHit, 0, len(hits))}
+	for _, h := range hits {
+		page.Hits = append(page.Hits, &domain.SearchHit{Post: &h.Post, Rank: h.Rank, Snippet: h.Snippet})
+	}
+	return trimSearchPage(page, q.Limit), nil
+}
+
+func (s *Storage) SearchComments(ctx context.Context, q *domain.SearchQuery) (*domain.SearchPage, error) {
+	matches := `SELECT c.*, ts_rank(d.document, query)::FLOAT8 AS rank
+				FROM comments c
+				JOIN comment_documents d ON d.comment_id = c.id
+				JOIN posts p ON p.id = c.post_id,
+				     websearch_to_tsquery('english', $1) query
+				WHERE d.document @@ query
+				  AND NOT c.deleted AND c.removed_at IS NULL
+				  AND NOT p.deleted AND p.removed_at IS NULL`
+	text := `h.text`
+
+	sql, args := searchSQL(q, matches, commentFilterColumns, text)
+	rows, _ := s.pool.Query(ctx, sql, args...)
+	hits, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[commentHit])
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.SearchPage{Hits: make([]*domain.SearchHit, 0, len(hits))}
+	for _, h := range hits {
+		page.Hits = append(page.Hits, &domain.SearchHit{Comment: &h.Comment, Rank: h.Rank, Snippet: h.Snippet})
+	}
+	return trimSearchPage(page, q.Limit), nil
+}
+
+// filterColumns name columns of matched rows checked by search filter.
+type filterColumns struct {
+	authorID, community, createdAt, rating string
+}
+
+var (
+	postFilterColumns    = filterColumns{authorID: "p.author_id", community: "p.community", createdAt: "p.created_at", rating: "p.rating"}
+	commentFilterColumns = filterColumns{authorID: "c.author_id", community: "p.community", createdAt: "c.created_at", rating: "c.rating"}
+)
+
+// searchSQL pages over matches, query selecting content rows with rank, where $1 is search text.
+// Snippets are built for rows of the page only, as headlines are expensive.
+func searchSQL(q *domain.SearchQuery, matches string, columns filterColumns, text string) (string, []any) {
+	args := []any{q.Text}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	f := q.Filter
+	if f.AuthorID != nil {
+		matches += fmt.Sprintf(" AND %s = %s", columns.authorID, arg(*f.AuthorID))
+	}
+	if f.Community != nil {
+		matches += fmt.Sprintf(" AND %s = %s", columns.community, arg(*f.Community))
+	}
+	if f.Before != nil {
+		matches += fmt.Sprintf(" AND %s < %s", columns.createdAt, arg(*f.Before))
+	}
+	if f.After != nil {
+		matches += fmt.Sprintf(" AND %s >= %s", columns.createdAt, arg(*f.After))
+	}
+	if f.MinRating != nil {
+		matches += fmt.Sprintf(" AND %s >= %s", columns.rating, arg(*f.MinRating))
+	}
+
+	after := "TRUE"
+	var order string
+	switch q.Sort {
+	case domain.SearchSortNew:
+		order = "created_at DESC, id ASC"
+		if c := q.TimeCursor; c != nil {
+			t, id := arg(c.Time), arg(c.ID)
+			after = fmt.Sprintf("created_at < %s OR (created_at = %s AND id > %s)", t, t, id)
+		}
+	case domain.SearchSortRating:
+		order = "rating DESC, id ASC"
+		if c := q.RatingCursor; c != nil {
+			r, id := arg(c.Rating), arg(c.ID)
+			after = fmt.Sprintf("rating < %s OR (rating = %s AND id > %s)", r, r, id)
+		}
+	default:
+		order = "rank DESC, id ASC"
+		if c := q.RankCursor; c != nil {
+			r, id := arg(c.Rank), arg(c.ID)
+			after = fmt.Sprintf("rank < %s OR (rank = %s AND id > %s)", r, r, id)
+		}
+	}
+	limit := arg(q.Limit + 1)
+
+	// Match marks are stripped from text, so that every mark in snippet comes from ts_headline
+	sql := fmt.Sprintf(`SELECT h.*, ts_headline('english', translate(%s, '%s', ''), websearch_to_tsquery('english', $1), '%s') AS snippet
+		FROM (SELECT * FROM (%s) m
+			  WHERE %s
+			  ORDER BY %s
+			  LIMIT %s) h
+		ORDER BY %s`, text, domain.SnippetMatchStart+domain.SnippetMatchStop, headlineOptions, matches, after, order, limit, order)
+	return sql, args
+}
+
+// trimSearchPage drops extra hit fetched to find out whether next page exists.
+func trimSearchPage(page *domain.SearchPage, limit int32) *domain.SearchPage {
+	page.HasNext = len(page.Hits) > int(limit)
+	if page.HasNext {
+		page.Hits = page.Hits[:limit]
+	}
+	return page
+}
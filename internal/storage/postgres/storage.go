@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -13,6 +15,7 @@ import (
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/config"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/ranking"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/storage"
 )
 
@@ -23,6 +26,7 @@ const (
 
 	commentsRestricted = "90001"
 	replyToDeleted     = "90002"
+	postDeleted        = "90003"
 )
 
 var _ storage.Storage = (*Storage)(nil)
@@ -80,16 +84,20 @@ func (s *Storage) CreateComment(ctx context.Context, input *domain.CreateComment
 			return nil, errs.CommentsRestricted
 		case replyToDeleted:
 			return nil, errs.ReplyToDeletedComment
+		case postDeleted:
+			return nil, errs.PostDeleted
 		}
+		return nil, err
 	}
 
 	return &comment, nil
 }
 
 func (s *Storage) CreatePost(ctx context.Context, input *domain.CreatePostInput) (*domain.Post, error) {
-	q := `INSERT INTO posts (author_id, title, content) 
-		  VALUES ($1, $2, $3) RETURNING *`
-	rows, _ := s.pool.Query(ctx, q, input.AuthorID, input.Title, input.Content)
+	q := `INSERT INTO posts (author_id, community, title, content, kind, url, domain, normalized_url) 
+		  VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *`
+	rows, _ := s.pool.Query(ctx, q, input.AuthorID, input.Community, input.Title, input.Content,
+		input.Kind, input.URL, input.Domain, input.NormalizedURL)
 	post, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.Post])
 	if err != nil {
 		return nil, err
@@ -98,31 +106,58 @@ func (s *Storage) CreatePost(ctx context.Context, input *domain.CreatePostInput)
 	return &post, nil
 }
 
-func (s *Storage) DeleteComment(ctx context.Context, id int) error {
-	q := `UPDATE comments 
+func (s *Storage) DeleteComment(ctx context.Context, id int, entry *domain.AddModLogEntryInput) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockComment(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	q := `UPDATE comments
 		  SET deleted = TRUE
-		  WHERE id = $1`
-	commandTag, err := s.pool.Exec(ctx, q, id)
+		  WHERE id = $1
+		  RETURNING *`
+	rows, _ := tx.Query(ctx, q, id)
+	comment, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Comment])
 	if err != nil {
 		return err
 	}
-	if commandTag.RowsAffected() == 0 {
-		return errs.CommentNotFound
+	if err := addModLogEntry(ctx, tx, entry, domain.CommentState(before), domain.CommentState(comment)); err != nil {
+		return err
 	}
-	return nil
+	return tx.Commit(ctx)
 }
 
-func (s *Storage) DeletePost(ctx context.Context, id int) error {
-	q := `DELETE FROM posts 
-		  WHERE id = $1`
-	commandTag, err := s.pool.Exec(ctx, q, id)
+func (s *Storage) DeletePost(ctx context.Context, id int, entry *domain.AddModLogEntryInput) error {
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
-	if commandTag.RowsAffected() == 0 {
-		return errs.PostNotFound
+	defer tx.Rollback(ctx)
+
+	before, err := lockPost(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	q := `UPDATE posts
+		  SET deleted = TRUE, deleted_at = COALESCE(deleted_at, NOW())
+		  WHERE id = $1
+		  RETURNING *`
+	rows, _ := tx.Query(ctx, q, id)
+	post, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Post])
+	if err != nil {
+		return err
+	}
+	if err := addModLogEntry(ctx, tx, entry, domain.PostState(before), domain.PostState(post)); err != nil {
+		return err
 	}
-	return nil
+	return tx.Commit(ctx)
 }
 
 func (s *Storage) GetComment(ctx context.Context, id int) (*domain.Comment, error) {
@@ -139,42 +174,506 @@ func (s *Storage) GetComment(ctx context.Context, id int) (*domain.Comment, erro
 	return &comment, nil
 }
 
-// GetPost implements storage.Storage.
 func (s *Storage) GetPost(ctx context.Context, id int) (*domain.Post, error) {
-	panic("unimplemented")
+	q := `SELECT * FROM posts
+		  WHERE id = $1`
+	rows, _ := s.pool.Query(ctx, q, id)
+	post, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.Post])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.PostNotFound
+		}
+		return nil, err
+	}
+	return &post, nil
 }
 
-// GetPostsSortedByRating implements storage.Storage.
-func (s *Storage) GetPostsSortedByRating(ctx context.Context, limit int32, cursor *domain.PostRatingCursor) (*domain.PostsPage, error) {
-	panic("unimplemented")
+func (s *Storage) GetPostsByIDs(ctx context.Context, ids []int) ([]*domain.Post, error) {
+	q := `SELECT * FROM posts
+		  WHERE id = ANY($1)`
+	rows, _ := s.pool.Query(ctx, q, ids)
+	return pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Post])
 }
 
-// GetPostsSortedByTime implements storage.Storage.
-func (s *Storage) GetPostsSortedByTime(ctx context.Context, limit int32, cursor *domain.PostTimeCursor, newFirst bool) (*domain.PostsPage, error) {
-	panic("unimplemented")
+func (s *Storage) GetCommentsByIDs(ctx context.Context, ids []int) ([]*domain.Comment, error) {
+	q := `SELECT * FROM comments
+		  WHERE id = ANY($1)`
+	rows, _ := s.pool.Query(ctx, q, ids)
+	return pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Comment])
+}
+
+func (s *Storage) GetPostVotes(ctx context.Context, voterID uuid.UUID, postIDs []int) (map[int]int8, error) {
+	q := `SELECT post_id, value FROM post_votes
+		  WHERE voter_id = $1 AND post_id = ANY($2)`
+	return s.collectVotes(ctx, q, voterID, postIDs)
+}
+
+func (s *Storage) GetCommentVotes(ctx context.Context, voterID uuid.UUID, commentIDs []int) (map[int]int8, error) {
+	q := `SELECT comment_id, value FROM comment_votes
+		  WHERE voter_id = $1 AND comment_id = ANY($2)`
+	return s.collectVotes(ctx, q, voterID, commentIDs)
+}
+
+// collectVotes runs query returning (id, value) pairs and collects them into map.
+func (s *Storage) collectVotes(ctx context.Context, q string, args ...any) (map[int]int8, error) {
+	rows, err := s.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := make(map[int]int8)
+	for rows.Next() {
+		var id int
+		var value int8
+		if err := rows.Scan(&id, &value); err != nil {
+			return nil, err
+		}
+		votes[id] = value
+	}
+	return votes, rows.Err()
 }
 
-// SetCommentsRestricted implements storage.Storage.
-func (s *Storage) SetCommentsRestricted(ctx context.Context, id int, restricted bool) (*domain.Post, error) {
-	panic("unimplemented")
+func (s *Storage) GetPostsSortedByRating(ctx context.Context, viewerID *uuid.UUID, filter domain.PostsFilter, limit int32, cursor *domain.PostRatingCursor) (*domain.PostsPage, error) {
+	q, args := filteredPosts(viewerID, filter)
+	if cursor != nil {
+		args = append(args, cursor.Rating, cursor.ID)
+		q += fmt.Sprintf(" AND (rating < $%d OR (rating = $%d AND id > $%d))", len(args)-1, len(args)-1, len(args))
+	}
+	args = append(args, limit+1)
+	q += fmt.Sprintf(" ORDER BY rating DESC, id ASC LIMIT $%d", len(args))
+
+	return s.collectPostsPage(ctx, limit, q, args...)
 }
 
-// UpdateCommentIfNotDeleted implements storage.Storage.
-func (s *Storage) UpdateCommentIfNotDeleted(ctx context.Context, input *domain.UpdateCommentInput) (*domain.Comment, error) {
-	panic("unimplemented")
+func (s *Storage) GetPostsSortedByTime(ctx context.Context, viewerID *uuid.UUID, filter domain.PostsFilter, limit int32, cursor *domain.PostTimeCursor, newFirst bool) (*domain.PostsPage, error) {
+	order, cmp := "ASC", ">"
+	if newFirst {
+		order, cmp = "DESC", "<"
+	}
+
+	q, args := filteredPosts(viewerID, filter)
+	if cursor != nil {
+		args = append(args, cursor.Time, cursor.ID)
+		q += fmt.Sprintf(" AND (created_at %s $%d OR (created_at = $%d AND id > $%d))", cmp, len(args)-1, len(args)-1, len(args))
+	}
+	args = append(args, limit+1)
+	q += fmt.Sprintf(" ORDER BY created_at %s, id ASC LIMIT $%d", order, len(args))
+
+	return s.collectPostsPage(ctx, limit, q, args...)
 }
 
-// UpdatePost implements storage.Storage.
-func (s *Storage) UpdatePost(ctx context.Context, input *domain.UpdatePostInput) (*domain.Post, error) {
-	panic("unimplemented")
+// visiblePosts is condition on posts skipping ones viewer $1 hid or wrote by users viewer blocked.
+// Filtering in query rather than after it keeps pages full. Null viewer sees everything.
+const visiblePosts = `NOT EXISTS (SELECT 1 FROM hidden_posts h WHERE h.user_id = $1 AND h.post_id = posts.id)
+	AND NOT EXISTS (SELECT 1 FROM blocked_users b WHERE b.user_id = $1 AND b.blocked_id = posts.author_id)`
+
+// filteredPosts starts query of posts visible to viewer and matching filter, further conditions may be appended to it.
+func filteredPosts(viewerID *uuid.UUID, filter domain.PostsFilter) (string, []any) {
+	q := `SELECT * FROM posts WHERE NOT deleted AND ` + visiblePosts
+	args := []any{viewerID}
+	if filter.Domain != nil {
+		args = append(args, *filter.Domain)
+		q += fmt.Sprintf(" AND domain = $%d AND removed_at IS NULL", len(args))
+	}
+	return q, args
+}
+
+func (s *Storage) GetRecentLinkPosts(ctx context.Context, community, normalizedURL string, since time.Time) ([]*domain.Post, error) {
+	q := `SELECT * FROM posts
+		  WHERE community = $1 AND normalized_url = $2 AND created_at >= $3
+		    AND NOT deleted AND removed_at IS NULL
+		  ORDER BY created_at DESC, id DESC`
+	rows, _ := s.pool.Query(ctx, q, community, normalizedURL, since)
+	return pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Post])
+}
+
+// collectPostsPage runs query fetching up to limit+1 posts and trims extra one into HasNext.
+func (s *Storage) collectPostsPage(ctx context.Context, limit int32, q string, args ...any) (*domain.PostsPage, error) {
+	rows, _ := s.pool.Query(ctx, q, args...)
+	posts, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Post])
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := len(posts) > int(limit)
+	if hasNext {
+		posts = posts[:limit]
+	}
+	return &domain.PostsPage{
+		Posts:   posts,
+		HasNext: hasNext,
+	}, nil
+}
+
+func (s *Storage) RestorePost(ctx context.Context, id int, entry *domain.AddModLogEntryInput) (*domain.Post, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockPost(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !before.Deleted {
+		return nil, errs.NotDeleted
+	}
+
+	q := `UPDATE posts
+		  SET deleted = FALSE, deleted_at = NULL
+		  WHERE id = $1
+		  RETURNING *`
+	rows, _ := tx.Query(ctx, q, id)
+	post, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Post])
+	if err != nil {
+		return nil, err
+	}
+	if err := addModLogEntry(ctx, tx, entry, domain.PostState(before), domain.PostState(post)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// PurgeDeletedPosts relies on cascade to delete comments and votes of posts.
+func (s *Storage) PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time) (int, error) {
+	q := `DELETE FROM posts
+		  WHERE deleted AND deleted_at < $1`
+	commandTag, err := s.pool.Exec(ctx, q, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return int(commandTag.RowsAffected()), nil
+}
+
+func (s *Storage) SetCommentsRestricted(ctx context.Context, id int, restricted bool, entry *domain.AddModLogEntryInput) (*domain.Post, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockPost(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `UPDATE posts
+		  SET comments_restricted = $2
+		  WHERE id = $1
+		  RETURNING *`
+	rows, _ := tx.Query(ctx, q, id, restricted)
+	post, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Post])
+	if err != nil {
+		return nil, err
+	}
+	if err := addModLogEntry(ctx, tx, entry, domain.PostState(before), domain.PostState(post)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return post, nil
 }
 
-// VoteCommentIfNotDeleted implements storage.Storage.
 func (s *Storage) VoteCommentIfNotDeleted(ctx context.Context, input *domain.CommentVote) (*domain.Comment, error) {
-	panic("unimplemented")
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var deleted, removed bool
+	var upvotes, downvotes int32
+	q := `SELECT deleted, removed_at IS NOT NULL, upvotes, downvotes FROM comments
+		  WHERE id = $1
+		  FOR UPDATE`
+	err = tx.QueryRow(ctx, q, input.ID).Scan(&deleted, &removed, &upvotes, &downvotes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.CommentNotFound
+		}
+		return nil, err
+	}
+	if deleted {
+		return nil, errs.CommentDeleted
+	}
+	if removed {
+		return nil, errs.CommentRemoved
+	}
+
+	var current int8
+	q = `SELECT value FROM comment_votes
+		 WHERE voter_id = $1 AND comment_id = $2`
+	err = tx.QueryRow(ctx, q, input.VoterID, input.ID).Scan(&current)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	next := nextVoteValue(current, input.Value)
+	if next == 0 {
+		q = `DELETE FROM comment_votes
+			 WHERE voter_id = $1 AND comment_id = $2`
+		_, err = tx.Exec(ctx, q, input.VoterID, input.ID)
+	} else {
+		q = `INSERT INTO comment_votes (voter_id, comment_id, value)
+			 VALUES ($1, $2, $3)
+			 ON CONFLICT (voter_id, comment_id) DO UPDATE SET value = EXCLUDED.value`
+		_, err = tx.Exec(ctx, q, input.VoterID, input.ID, next)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	upvotes, downvotes = recountVotes(upvotes, downvotes, current, next)
+	q = `UPDATE comments
+		 SET rating = rating + $2, upvotes = $3, downvotes = $4, best_score = $5
+		 WHERE id = $1
+		 RETURNING *`
+	rows, _ := tx.Query(ctx, q, input.ID, next-current, upvotes, downvotes, ranking.Wilson(upvotes, downvotes))
+	comment, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.Comment])
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (s *Storage) GetCommentsSortedByRating(ctx context.Context, viewerID *uuid.UUID, postID int, parentID *int, limit int32, cursor *domain.PostRatingCursor) (*domain.CommentsPage, error) {
+	q, args := siblingsQuery(viewerID, postID, parentID)
+	if cursor != nil {
+		args = append(args, cursor.Rating, cursor.ID)
+		q += fmt.Sprintf(" AND (rating < $%d OR (rating = $%d AND id > $%d))", len(args)-1, len(args)-1, len(args))
+	}
+	args = append(args, limit+1)
+	q += fmt.Sprintf(" ORDER BY rating DESC, id ASC LIMIT $%d", len(args))
+
+	return s.collectCommentsPage(ctx, limit, q, args...)
+}
+
+func (s *Storage) GetCommentsSortedByTime(ctx context.Context, viewerID *uuid.UUID, postID int, parentID *int, limit int32, cursor *domain.PostTimeCursor, newFirst bool) (*domain.CommentsPage, error) {
+	order, cmp := "ASC", ">"
+	if newFirst {
+		order, cmp = "DESC", "<"
+	}
+
+	q, args := siblingsQuery(viewerID, postID, parentID)
+	if cursor != nil {
+		args = append(args, cursor.Time, cursor.ID)
+		q += fmt.Sprintf(" AND (created_at %s $%d OR (created_at = $%d AND id > $%d))", cmp, len(args)-1, len(args)-1, len(args))
+	}
+	args = append(args, limit+1)
+	q += fmt.Sprintf(" ORDER BY created_at %s, id ASC LIMIT $%d", order, len(args))
+
+	return s.collectCommentsPage(ctx, limit, q, args...)
+}
+
+func (s *Storage) GetCommentsSortedByBest(ctx context.Context, viewerID *uuid.UUID, postID int, parentID *int, limit int32, cursor *domain.CommentBestCursor) (*domain.CommentsPage, error) {
+	q, args := siblingsQuery(viewerID, postID, parentID)
+	if cursor != nil {
+		args = append(args, cursor.Score, cursor.ID)
+		q += fmt.Sprintf(" AND (best_score < $%d OR (best_score = $%d AND id > $%d))", len(args)-1, len(args)-1, len(args))
+	}
+	args = append(args, limit+1)
+	q += fmt.Sprintf(" ORDER BY best_score DESC, id ASC LIMIT $%d", len(args))
+
+	return s.collectCommentsPage(ctx, limit, q, args...)
+}
+
+func (s *Storage) GetCommentsFirstPages(ctx context.Context, viewerID *uuid.UUID, parents []domain.CommentsParent, sort domain.SortOrder, limit int32) ([]*domain.CommentsPage, error) {
+	var order string
+	switch sort {
+	case domain.SortOrderRating:
+		order = "c.rating DESC, c.id ASC"
+	case domain.SortOrderNew:
+		order = "c.created_at DESC, c.id ASC"
+	case domain.SortOrderOld:
+		order = "c.created_at ASC, c.id ASC"
+	case domain.SortOrderBest:
+		order = "c.best_score DESC, c.id ASC"
+	default:
+		return nil, fmt.Errorf("unknown sort order %q", sort)
+	}
+
+	postIDs := make([]int, len(parents))
+	parentIDs := make([]*int, len(parents))
+	for i, p := range parents {
+		postIDs[i] = p.PostID
+		parentIDs[i] = p.ParentID
+	}
+
+	// Number siblings within every requested parent and take one extra row to find out if there is next page
+	q := fmt.Sprintf(`SELECT (t.c).* FROM (
+			SELECT c, ROW_NUMBER() OVER (PARTITION BY c.post_id, c.parent_id ORDER BY %s) AS rn
+			FROM comments c
+			JOIN unnest($1::bigint[], $2::bigint[]) AS p (post_id, parent_id)
+			  ON c.post_id = p.post_id AND c.parent_id IS NOT DISTINCT FROM p.parent_id
+			WHERE NOT EXISTS (SELECT 1 FROM blocked_users b WHERE b.user_id = $4 AND b.blocked_id = c.author_id)
+		  ) t
+		  WHERE t.rn <= $3
+		  ORDER BY t.rn`, order)
+	rows, _ := s.pool.Query(ctx, q, postIDs, parentIDs, limit+1, viewerID)
+	comments, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Comment])
+	if err != nil {
+		return nil, err
+	}
+
+	type parentKey struct {
+		postID   int
+		parentID int // 0 for top-level comments
+	}
+	keyOf := func(postID int, parentID *int) parentKey {
+		if parentID == nil {
+			return parentKey{postID: postID}
+		}
+		return parentKey{postID: postID, parentID: *parentID}
+	}
+
+	byParent := make(map[parentKey]*domain.CommentsPage, len(parents))
+	for _, p := range parents {
+		byParent[keyOf(p.PostID, p.ParentID)] = &domain.CommentsPage{Comments: []*domain.Comment{}}
+	}
+	for _, c := range comments {
+		page := byParent[keyOf(c.PostID, c.ParentID)]
+		if len(page.Comments) == int(limit) {
+			page.HasNext = true
+			continue
+		}
+		page.Comments = append(page.Comments, c)
+	}
+
+	pages := make([]*domain.CommentsPage, len(parents))
+	for i, p := range parents {
+		pages[i] = byParent[keyOf(p.PostID, p.ParentID)]
+	}
+	return pages, nil
+}
+
+// siblingsQuery returns base query selecting top-level comments of post if parentID is nil
+// and direct replies to parentID otherwise, skipping comments of users viewer blocked, along with its arguments.
+func siblingsQuery(viewerID *uuid.UUID, postID int, parentID *int) (string, []any) {
+	visible := ` AND NOT EXISTS (SELECT 1 FROM blocked_users b WHERE b.user_id = $1 AND b.blocked_id = comments.author_id)`
+	if parentID == nil {
+		return `SELECT * FROM comments WHERE post_id = $2 AND parent_id IS NULL` + visible, []any{viewerID, postID}
+	}
+	return `SELECT * FROM comments WHERE post_id = $2 AND parent_id = $3` + visible, []any{viewerID, postID, *parentID}
+}
+
+// collectCommentsPage runs query fetching up to limit+1 comments and trims extra one into HasNext.
+func (s *Storage) collectCommentsPage(ctx context.Context, limit int32, q string, args ...any) (*domain.CommentsPage, error) {
+	rows, _ := s.pool.Query(ctx, q, args...)
+	comments, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Comment])
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := len(comments) > int(limit)
+	if hasNext {
+		comments = comments[:limit]
+	}
+	return &domain.CommentsPage{
+		Comments: comments,
+		HasNext:  hasNext,
+	}, nil
+}
+
+// nextVoteValue returns vote value stored after voter with current vote (0 if none) submits requested one.
+// Repeating the same vote retracts it.
+func nextVoteValue(current, requested int8) int8 {
+	if current == requested {
+		return 0
+	}
+	return requested
+}
+
+// recountVotes returns upvotes and downvotes counters after vote changed from prev to next (0 means no vote).
+func recountVotes(upvotes, downvotes int32, prev, next int8) (int32, int32) {
+	switch prev {
+	case 1:
+		upvotes--
+	case -1:
+		downvotes--
+	}
+	switch next {
+	case 1:
+		upvotes++
+	case -1:
+		downvotes++
+	}
+	return upvotes, downvotes
 }
 
-// VotePost implements storage.Storage.
 func (s *Storage) VotePost(ctx context.Context, vote *domain.PostVote) (*domain.Post, error) {
-	panic("unimplemented")
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var deleted, removed bool
+	var upvotes, downvotes int32
+	q := `SELECT deleted, removed_at IS NOT NULL, upvotes, downvotes FROM posts
+		  WHERE id = $1
+		  FOR UPDATE`
+	err = tx.QueryRow(ctx, q, vote.ID).Scan(&deleted, &removed, &upvotes, &downvotes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.PostNotFound
+		}
+		return nil, err
+	}
+	if deleted {
+		return nil, errs.PostDeleted
+	}
+	if removed {
+		return nil, errs.PostRemoved
+	}
+
+	var current int8
+	q = `SELECT value FROM post_votes
+		 WHERE voter_id = $1 AND post_id = $2`
+	err = tx.QueryRow(ctx, q, vote.VoterID, vote.ID).Scan(&current)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	next := nextVoteValue(current, vote.Value)
+	if next == 0 {
+		q = `DELETE FROM post_votes
+			 WHERE voter_id = $1 AND post_id = $2`
+		_, err = tx.Exec(ctx, q, vote.VoterID, vote.ID)
+	} else {
+		q = `INSERT INTO post_votes (voter_id, post_id, value)
+			 VALUES ($1, $2, $3)
+			 ON CONFLICT (voter_id, post_id) DO UPDATE SET value = EXCLUDED.value`
+		_, err = tx.Exec(ctx, q, vote.VoterID, vote.ID, next)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	upvotes, downvotes = recountVotes(upvotes, downvotes, current, next)
+	q = `UPDATE posts
+		 SET rating = rating + $2, upvotes = $3, downvotes = $4
+		 WHERE id = $1
+		 RETURNING *`
+	rows, _ := tx.Query(ctx, q, vote.ID, next-current, upvotes, downvotes)
+	post, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[domain.Post])
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &post, nil
 }
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
+)
+
+func (s *Storage) HidePost(ctx context.Context, userID uuid.UUID, postID int) error {
+	q := `INSERT INTO hidden_posts (user_id, post_id)
+		  VALUES ($1, $2)
+		  ON CONFLICT DO NOTHING`
+	_, err := s.pool.Exec(ctx, q, userID, postID)
+	return err
+}
+
+func (s *Storage) UnhidePost(ctx context.Context, userID uuid.UUID, postID int) error {
+	q := `DELETE FROM hidden_posts WHERE user_id = $1 AND post_id = $2`
+	_, err := s.pool.Exec(ctx, q, userID, postID)
+	return err
+}
+
+func (s *Storage) BlockUser(ctx context.Context, userID, blockedID uuid.UUID) (*domain.Block, error) {
+	// No-op update makes conflicting row returned as well
+	q := `INSERT INTO blocked_users (user_id, blocked_id)
+		  VALUES ($1, $2)
+		  ON CONFLICT (user_id, blocked_id) DO UPDATE SET created_at = blocked_users.created_at
+		  RETURNING *`
+	rows, _ := s.pool.Query(ctx, q, userID, blockedID)
+	return pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByName[domain.Block])
+}
+
+func (s *Storage) UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	q := `DELETE FROM blocked_users WHERE user_id = $1 AND blocked_id = $2`
+	_, err := s.pool.Exec(ctx, q, userID, blockedID)
+	return err
+}
+
+func (s *Storage) GetBlocks(ctx context.Context, userID uuid.UUID) ([]*domain.Block, error) {
+	q := `SELECT * FROM blocked_users
+		  WHERE user_id = $1
+		  ORDER BY created_at DESC`
+	rows, _ := s.pool.Query(ctx, q, userID)
+	return pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[domain.Block])
+}
@@ -2,6 +2,9 @@ package storage
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/domain"
 )
@@ -9,25 +12,179 @@ import (
 type Storage interface {
 	Post
 	Comment
+	RateLimit
+	Role
+	Report
+	ModLog
+	Ban
+	Search
+	Saved
+	Visibility
+	Idempotency
+	Preview
 	Close()
 }
 
 type Post interface {
 	CreatePost(ctx context.Context, input *domain.CreatePostInput) (*domain.Post, error)
 	GetPost(ctx context.Context, id int) (*domain.Post, error)
+	// GetPostsByIDs returns existing posts among ids in no particular order.
+	GetPostsByIDs(ctx context.Context, ids []int) ([]*domain.Post, error)
+	// UpdatePost records replaced version of post as revision along with edit, edit changing nothing is not recorded.
 	UpdatePost(ctx context.Context, input *domain.UpdatePostInput) (*domain.Post, error)
-	DeletePost(ctx context.Context, id int) error
-	SetCommentsRestricted(ctx context.Context, id int, restricted bool) (*domain.Post, error)
+	// GetPostRevisions returns revisions of post, oldest first.
+	GetPostRevisions(ctx context.Context, postID int) ([]*domain.PostRevision, error)
+	// DeletePost marks post deleted, it stays restorable until purged.
+	DeletePost(ctx context.Context, id int, entry *domain.AddModLogEntryInput) error
+	RestorePost(ctx context.Context, id int, entry *domain.AddModLogEntryInput) (*domain.Post, error)
+	// PurgeDeletedPosts permanently deletes posts deleted before given time along with their comments and votes.
+	PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time) (int, error)
+	SetCommentsRestricted(ctx context.Context, id int, restricted bool, entry *domain.AddModLogEntryInput) (*domain.Post, error)
+	// RemovePost hides post on behalf of moderator, removing already removed post updates removal.
+	RemovePost(ctx context.Context, input *domain.RemoveInput, entry *domain.AddModLogEntryInput) (*domain.Post, error)
+	ApprovePost(ctx context.Context, id int, entry *domain.AddModLogEntryInput) (*domain.Post, error)
 	VotePost(ctx context.Context, vote *domain.PostVote) (*domain.Post, error)
+	// GetPostVotes returns values of votes voterID gave to given posts, posts without vote are omitted.
+	GetPostVotes(ctx context.Context, voterID uuid.UUID, postIDs []int) (map[int]int8, error)
+
+	// GetRecentLinkPosts returns posts of community linking the same page created since given time, newest first.
+	// Deleted and removed posts are skipped.
+	GetRecentLinkPosts(ctx context.Context, community, normalizedURL string, since time.Time) ([]*domain.Post, error)
 
-	GetPostsSortedByRating(ctx context.Context, limit int32, cursor *domain.PostRatingCursor) (*domain.PostsPage, error)
-	GetPostsSortedByTime(ctx context.Context, limit int32, cursor *domain.PostTimeCursor, newFirst bool) (*domain.PostsPage, error)
+	// Posts listing methods skip deleted posts, along with posts viewer hid and posts of users viewer blocked.
+	// Anonymous viewer is nil.
+	GetPostsSortedByRating(ctx context.Context, viewerID *uuid.UUID, filter domain.PostsFilter, limit int32, cursor *domain.PostRatingCursor) (*domain.PostsPage, error)
+	GetPostsSortedByTime(ctx context.Context, viewerID *uuid.UUID, filter domain.PostsFilter, limit int32, cursor *domain.PostTimeCursor, newFirst bool) (*domain.PostsPage, error)
 }
 
 type Comment interface {
 	CreateComment(ctx context.Context, input *domain.CreateCommentInput) (*domain.Comment, error)
+	// UpdateCommentIfNotDeleted records replaced version of comment as revision the same way as UpdatePost.
 	UpdateCommentIfNotDeleted(ctx context.Context, input *domain.UpdateCommentInput) (*domain.Comment, error)
-	DeleteComment(ctx context.Context, id int) error
+	// GetCommentRevisions returns revisions of comment, oldest first.
+	GetCommentRevisions(ctx context.Context, commentID int) ([]*domain.CommentRevision, error)
+	DeleteComment(ctx context.Context, id int, entry *domain.AddModLogEntryInput) error
+	// RemoveComment hides comment on behalf of moderator, comments deleted by author cannot be removed.
+	RemoveComment(ctx context.Context, input *domain.RemoveInput, entry *domain.AddModLogEntryInput) (*domain.Comment, error)
+	ApproveComment(ctx context.Context, id int, entry *domain.AddModLogEntryInput) (*domain.Comment, error)
 	VoteCommentIfNotDeleted(ctx context.Context, input *domain.CommentVote) (*domain.Comment, error)
 	GetComment(ctx context.Context, id int) (*domain.Comment, error)
+	// GetCommentsByIDs returns existing comments among ids in no particular order.
+	GetCommentsByIDs(ctx context.Context, ids []int) ([]*domain.Comment, error)
+	// GetCommentVotes returns values of votes voterID gave to given comments, comments without vote are omitted.
+	GetCommentVotes(ctx context.Context, voterID uuid.UUID, commentIDs []int) (map[int]int8, error)
+
+	// Comments listing methods return top-level comments of post when parentID is nil
+	// and direct replies to parentID otherwise. They skip comments of users viewer blocked,
+	// anonymous viewer is nil.
+	GetCommentsSortedByRating(ctx context.Context, viewerID *uuid.UUID, postID int, parentID *int, limit int32, cursor *domain.PostRatingCursor) (*domain.CommentsPage, error)
+	GetCommentsSortedByTime(ctx context.Context, viewerID *uuid.UUID, postID int, parentID *int, limit int32, cursor *domain.PostTimeCursor, newFirst bool) (*domain.CommentsPage, error)
+	GetCommentsSortedByBest(ctx context.Context, viewerID *uuid.UUID, postID int, parentID *int, limit int32, cursor *domain.CommentBestCursor) (*domain.CommentsPage, error)
+	// GetCommentsFirstPages returns first page of comments for every parent at once, pages are ordered as parents.
+	GetCommentsFirstPages(ctx context.Context, viewerID *uuid.UUID, parents []domain.CommentsParent, sort domain.SortOrder, limit int32) ([]*domain.CommentsPage, error)
+}
+
+type RateLimit interface {
+	// TakeToken takes token from bucket identified by key.
+	// If bucket is empty it returns false and time until next token is added.
+	TakeToken(ctx context.Context, key string, limit domain.RateLimit) (bool, time.Duration, error)
+	// DeleteIdleBuckets deletes buckets last taken from before given time and returns their number.
+	DeleteIdleBuckets(ctx context.Context, updatedBefore time.Time) (int, error)
+}
+
+type Role interface {
+	// AddModerator is idempotent, it returns existing moderator if user already moderates community
+	// and logs entry only when user is appointed.
+	AddModerator(ctx context.Context, community string, userID uuid.UUID, entry *domain.AddModLogEntryInput) (*domain.Moderator, error)
+	RemoveModerator(ctx context.Context, community string, userID uuid.UUID, entry *domain.AddModLogEntryInput) error
+	IsModerator(ctx context.Context, community string, userID uuid.UUID) (bool, error)
+	// GetModerators returns moderators of community, oldest first.
+	GetModerators(ctx context.Context, community string) ([]*domain.Moderator, error)
+}
+
+type Report interface {
+	// CreateReport returns existing report instead if reporter already reported the same content.
+	CreateReport(ctx context.Context, input *domain.CreateReportInput) (*domain.Report, error)
+	GetReport(ctx context.Context, id int) (*domain.Report, error)
+	// ResolveReport sets status of open report along with every other open report of the same content.
+	ResolveReport(ctx context.Context, input *domain.ResolveReportInput, entry *domain.AddModLogEntryInput) (*domain.Report, error)
+	// GetReports returns reports of community with given status, oldest first.
+	GetReports(ctx context.Context, community string, status domain.ReportStatus, limit int32, afterID *int) (*domain.ReportsPage, error)
+}
+
+// ModLog entries are appended by methods of actions they record and are never updated or deleted.
+// Those methods take nil entry when action is not logged.
+type ModLog interface {
+	// GetModLog returns entries matching filter, newest first.
+	GetModLog(ctx context.Context, filter *domain.ModLogFilter, limit int32, beforeID *int) (*domain.ModLogPage, error)
+}
+
+// Ban methods take nil community for global bans.
+type Ban interface {
+	// BanUser replaces existing ban of user in the same community.
+	BanUser(ctx context.Context, input *domain.BanInput, entry *domain.AddModLogEntryInput) (*domain.Ban, error)
+	// UnbanUser lifts active ban, it returns errs.BanNotFound if user is not banned.
+	UnbanUser(ctx context.Context, community *string, userID uuid.UUID, entry *domain.AddModLogEntryInput) (*domain.Ban, error)
+	// GetActiveBan returns active ban of user in community or global one, errs.BanNotFound if there is none.
+	GetActiveBan(ctx context.Context, community string, userID uuid.UUID) (*domain.Ban, error)
+	// GetActiveBans returns active bans in community, newest first.
+	GetActiveBans(ctx context.Context, community *string) ([]*domain.Ban, error)
+}
+
+// Search methods skip deleted and removed content along with comments of such posts.
+type Search interface {
+	SearchPosts(ctx context.Context, q *domain.SearchQuery) (*domain.SearchPage, error)
+	SearchComments(ctx context.Context, q *domain.SearchQuery) (*domain.SearchPage, error)
+}
+
+// Saved methods take type of items, ids refer to posts or comments accordingly.
+type Saved interface {
+	// SaveItem is idempotent, saving already saved item keeps its original save time.
+	SaveItem(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, itemID int) (*domain.SavedItem, error)
+	// UnsaveItem is idempotent, unsaving item that is not saved does nothing.
+	UnsaveItem(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, itemID int) error
+	// GetSavedIDs returns which of given items user saved, items that are not saved are omitted.
+	GetSavedIDs(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, itemIDs []int) (map[int]bool, error)
+	// GetSavedItems returns items user saved, most recently saved first.
+	GetSavedItems(ctx context.Context, userID uuid.UUID, itemType domain.SavedType, limit int32, cursor *domain.PostTimeCursor) (*domain.SavedPage, error)
+}
+
+// Visibility holds what users chose not to see, listings of posts and comments apply it.
+type Visibility interface {
+	// HidePost is idempotent.
+	HidePost(ctx context.Context, userID uuid.UUID, postID int) error
+	// UnhidePost is idempotent.
+	UnhidePost(ctx context.Context, userID uuid.UUID, postID int) error
+	// BlockUser is idempotent, it returns existing block if user is already blocked.
+	BlockUser(ctx context.Context, userID, blockedID uuid.UUID) (*domain.Block, error)
+	// UnblockUser is idempotent.
+	UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error
+	// GetBlocks returns users blocked by userID, most recently blocked first.
+	GetBlocks(ctx context.Context, userID uuid.UUID) ([]*domain.Block, error)
+}
+
+// Idempotency keys are identified by user, operation and key.
+type Idempotency interface {
+	// ReserveIdempotencyKey stores key without result unless unexpired key exists, which is returned instead.
+	// Expired key is replaced. Nil is returned when key is reserved.
+	ReserveIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) (*domain.IdempotencyKey, error)
+	// CompleteIdempotencyKey sets id of item created by operation as result of reserved key and moves its expiration.
+	// Keys that already have result are left intact.
+	CompleteIdempotencyKey(ctx context.Context, userID uuid.UUID, operation domain.IdempotencyOperation, key string, resultID int, expiresAt time.Time) error
+	// ReleaseIdempotencyKey deletes key reserved by failed operation, so that it can be retried.
+	ReleaseIdempotencyKey(ctx context.Context, userID uuid.UUID, operation domain.IdempotencyOperation, key string) error
+	// DeleteExpiredIdempotencyKeys returns number of deleted keys.
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int, error)
+}
+
+// Preview holds previews of links by normalized url.
+type Preview interface {
+	// SaveLinkPreview replaces preview of the same normalized url.
+	SaveLinkPreview(ctx context.Context, preview *domain.LinkPreview) error
+	// GetLinkPreviews returns previews of given posts, posts without preview are omitted.
+	GetLinkPreviews(ctx context.Context, postIDs []int) (map[int]*domain.LinkPreview, error)
+	// GetLinksToPreview returns up to limit distinct links of not deleted posts that have no preview,
+	// or whose preview was fetched before staleBefore and before the post was made.
+	// Stale previews of old posts are kept, so that pages linked long ago are not fetched again and again.
+	GetLinksToPreview(ctx context.Context, staleBefore time.Time, limit int) ([]*domain.Link, error)
 }
@@ -0,0 +1,221 @@
+// Package textdiff compares texts line by line and formats difference as unified diff.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is number of unchanged lines shown around every change.
+const context = 3
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+// op is single line of edit script, aLine and bLine are indexes of line in old and new text.
+type op struct {
+	kind         opKind
+	aLine, bLine int
+	text         string
+}
+
+// Unified returns unified diff turning from into to, labeled with fromName and toName.
+// It is empty when texts are equal.
+func Unified(fromName, toName, from, to string) string {
+	a, b := lines(from), lines(to)
+	ops := editScript(a, b)
+
+	var out strings.Builder
+	for _, h := range hunks(ops) {
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		writeHunk(&out, ops[h.start:h.end])
+	}
+	return out.String()
+}
+
+// Versions returns unified diff between versions of text numbered from 1, ok is false if either number is out of range.
+func Versions(versions []string, from, to int) (diff string, ok bool) {
+	if from < 1 || from > len(versions) || to < 1 || to > len(versions) {
+		return "", false
+	}
+	return Unified(fmt.Sprintf("revision %d", from), fmt.Sprintf("revision %d", to), versions[from-1], versions[to-1]), true
+}
+
+// lines splits text into lines, final newline does not start another line.
+func lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// maxSearch bounds edit distance that search for middle snake explores. Texts differing more are not split
+// any further and remaining lines are reported as replaced, so that diff of unrelated texts stays cheap.
+const maxSearch = 256
+
+// editScript finds shortest edit script with linear space variant of Myers algorithm,
+// it takes O((N+M)D) time and O(N+M) space.
+func editScript(a, b []string) []op {
+	s := &scripter{a: a, b: b}
+	s.script(0, len(a), 0, len(b))
+	return s.ops
+}
+
+type scripter struct {
+	a, b []string
+	ops  []op
+}
+
+// script appends edits turning a[aLo:aHi] into b[bLo:bHi].
+func (s *scripter) script(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && s.a[aLo] == s.b[bLo] {
+		s.equal(aLo, bLo)
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && s.a[aHi-suffix-1] == s.b[bHi-suffix-1] {
+		suffix++
+	}
+	aHi, bHi = aHi-suffix, bHi-suffix
+
+	if aLo < aHi && bLo < bHi {
+		if x, y, u, v, ok := s.middleSnake(aLo, aHi, bLo, bHi); ok {
+			s.script(aLo, x, bLo, y)
+			for ; x < u; x, y = x+1, y+1 {
+				s.equal(x, y)
+			}
+			s.script(u, aHi, v, bHi)
+			aLo, bLo = aHi, bHi
+		}
+	}
+	for ; aLo < aHi; aLo++ {
+		s.ops = append(s.ops, op{kind: opDelete, aLine: aLo, bLine: bLo, text: s.a[aLo]})
+	}
+	for ; bLo < bHi; bLo++ {
+		s.ops = append(s.ops, op{kind: opInsert, aLine: aHi, bLine: bLo, text: s.b[bLo]})
+	}
+
+	for i := 0; i < suffix; i++ {
+		s.equal(aHi+i, bHi+i)
+	}
+}
+
+func (s *scripter) equal(x, y int) {
+	s.ops = append(s.ops, op{kind: opEqual, aLine: x, bLine: y, text: s.a[x]})
+}
+
+// middleSnake finds snake from (x, y) to (u, v) lying in the middle of shortest edit script,
+// searching from both ends of texts at once. It is not found when edit distance exceeds maxSearch.
+func (s *scripter) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int, ok bool) {
+	a, b := s.a[aLo:aHi], s.b[bLo:bHi]
+	n, m := len(a), len(b)
+	delta := n - m
+	maxD := min((n+m+1)/2, maxSearch/2)
+
+	// forward[k] is furthest x reached on diagonal k = x-y from the start,
+	// backward[c] is furthest n-x reached on diagonal c = (n-x)-(m-y) from the end
+	offset := maxD + 1
+	forward := make([]int, 2*maxD+3)
+	backward := make([]int, 2*maxD+3)
+
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+			if c := delta - k; delta%2 != 0 && c >= -(d-1) && c <= d-1 && x+backward[offset+c] >= n {
+				return aLo + x0, bLo + y0, aLo + x, bLo + y, true
+			}
+		}
+		for c := -d; c <= d; c += 2 {
+			var rx int
+			if c == -d || (c != d && backward[offset+c-1] < backward[offset+c+1]) {
+				rx = backward[offset+c+1]
+			} else {
+				rx = backward[offset+c-1] + 1
+			}
+			ry := rx - c
+			rx0, ry0 := rx, ry
+			for rx < n && ry < m && a[n-rx-1] == b[m-ry-1] {
+				rx++
+				ry++
+			}
+			backward[offset+c] = rx
+			if k := delta - c; delta%2 == 0 && k >= -d && k <= d && rx+forward[offset+k] >= n {
+				return aLo + n - rx, bLo + m - ry, aLo + n - rx0, bLo + m - ry0, true
+			}
+		}
+	}
+	return 0, 0, 0, 0, false
+}
+
+type hunk struct {
+	start, end int
+}
+
+// hunks groups changes with surrounding context, changes whose contexts touch share hunk.
+func hunks(ops []op) []hunk {
+	var result []hunk
+	for i, o := range ops {
+		if o.kind == opEqual {
+			continue
+		}
+		start, end := max(0, i-context), min(len(ops), i+context+1)
+		if n := len(result); n > 0 && start <= result[n-1].end {
+			result[n-1].end = end
+			continue
+		}
+		result = append(result, hunk{start: start, end: end})
+	}
+	return result
+}
+
+func writeHunk(out *strings.Builder, ops []op) {
+	aStart, bStart := ops[0].aLine, ops[0].bLine
+	var aCount, bCount int
+	for _, o := range ops {
+		if o.kind != opInsert {
+			aCount++
+		}
+		if o.kind != opDelete {
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+	for _, o := range ops {
+		out.WriteByte(byte(o.kind))
+		out.WriteString(o.text)
+		out.WriteByte('\n')
+	}
+}
+
+// hunkRange formats 0-based start and count of lines as 1-based range,
+// empty range refers to line preceding it.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
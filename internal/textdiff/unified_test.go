@@ -0,0 +1,152 @@
+package textdiff
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     string
+	}{
+		{"equal texts", "a\nb\n", "a\nb\n", ""},
+		{"both empty", "", "", ""},
+		{"from empty", "", "a\nb", "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"to empty", "a\nb", "", "--- old\n+++ new\n@@ -1,2 +0,0 @@\n-a\n-b\n"},
+		{"single line changed", "a", "b", "--- old\n+++ new\n@@ -1 +1 @@\n-a\n+b\n"},
+		{
+			"appended line",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10",
+			"--- old\n+++ new\n@@ -7,3 +7,4 @@\n 7\n 8\n 9\n+10\n",
+		},
+		{
+			"distant changes get own hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12",
+			"x\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ny",
+			"--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+y\n",
+		},
+		{
+			"close changes share hunk",
+			"1\n2\n3\n4\n5\n6\n7",
+			"1\nx\n3\n4\n5\n6\ny",
+			"--- old\n+++ new\n@@ -1,7 +1,7 @@\n 1\n-2\n+x\n 3\n 4\n 5\n 6\n-7\n+y\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("old", "new", tt.from, tt.to); got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionsChecksRange(t *testing.T) {
+	versions := []string{"a", "b"}
+	for _, r := range [][2]int{{0, 1}, {1, 3}, {3, 1}} {
+		if _, ok := Versions(versions, r[0], r[1]); ok {
+			t.Errorf("Versions(%d, %d) ok = true, want false", r[0], r[1])
+		}
+	}
+	if diff, ok := Versions(versions, 1, 2); !ok || !strings.HasPrefix(diff, "--- revision 1\n+++ revision 2\n") {
+		t.Errorf("Versions(1, 2) = %q, %v", diff, ok)
+	}
+}
+
+func TestEditScriptIsShortest(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rnd.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rnd.Intn(4)))
+		}
+		return lines
+	}
+	for i := 0; i < 2000; i++ {
+		a, b := randomLines(), randomLines()
+		ops := editScript(a, b)
+		checkScript(t, a, b, ops)
+
+		edits := 0
+		for _, o := range ops {
+			if o.kind != opEqual {
+				edits++
+			}
+		}
+		if want := len(a) + len(b) - 2*lcs(a, b); edits != want {
+			t.Fatalf("editScript(%q, %q) has %d edits, want %d", a, b, edits, want)
+		}
+	}
+}
+
+func TestEditScriptOfLargeUnrelatedTexts(t *testing.T) {
+	a, b := make([]string, 20000), make([]string, 20000)
+	for i := range a {
+		a[i], b[i] = fmt.Sprintf("a%d", i), fmt.Sprintf("b%d", i)
+	}
+	// Every other line is shared, so that texts are neither equal nor trivially unrelated
+	for i := 0; i < len(b); i += 2 {
+		b[i] = a[i+1]
+	}
+
+	start := time.Now()
+	ops := editScript(a, b)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("editScript() of %d lines took %v", len(a), elapsed)
+	}
+	checkScript(t, a, b, ops)
+}
+
+// checkScript fails test unless ops turn a into b and number lines of both texts in order.
+func checkScript(t *testing.T, a, b []string, ops []op) {
+	t.Helper()
+	x, y := 0, 0
+	for _, o := range ops {
+		if o.aLine != x || o.bLine != y {
+			t.Fatalf("op %+v is at (%d, %d)", o, x, y)
+		}
+		switch o.kind {
+		case opEqual:
+			if a[x] != b[y] || o.text != a[x] {
+				t.Fatalf("equal op %+v joins %q and %q", o, a[x], b[y])
+			}
+			x++
+			y++
+		case opDelete:
+			if o.text != a[x] {
+				t.Fatalf("delete op %+v, want text %q", o, a[x])
+			}
+			x++
+		case opInsert:
+			if o.text != b[y] {
+				t.Fatalf("insert op %+v, want text %q", o, b[y])
+			}
+			y++
+		}
+	}
+	if x != len(a) || y != len(b) {
+		t.Fatalf("script ends at (%d, %d), want (%d, %d)", x, y, len(a), len(b))
+	}
+}
+
+// lcs returns length of longest common subsequence of lines.
+func lcs(a, b []string) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(cur[j], prev[j+1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,153 @@
+// Package unfurl extracts previews of linked pages from their OpenGraph and HTML meta tags.
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// Redirects followed before fetch is given up
+const maxRedirects = 5
+
+var (
+	BlockedAddressErr = errors.New("address is not public")
+	NotHTMLErr        = errors.New("page is not html")
+	TooManyRedirects  = errors.New("too many redirects")
+)
+
+// Page is start of fetched HTML page.
+type Page struct {
+	// URL of page after redirects, relative urls of page resolve against it
+	URL  *url.URL
+	Body []byte
+}
+
+// Fetcher downloads HTML page at url. Implementations other than HTTPFetcher are meant for tests.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) (*Page, error)
+}
+
+// HTTPFetcher fetches pages from public addresses only, so that users cannot make server
+// request its internal network by posting links to it. Addresses are checked when connection
+// is made, after name resolution, so that names resolving to private addresses are blocked as well.
+type HTTPFetcher struct {
+	client *http.Client
+	// Longer pages are truncated, meta tags are expected at start of page
+	maxBytes int64
+}
+
+func NewHTTPFetcher(timeout time.Duration, maxBytes int64) *HTTPFetcher {
+	return newHTTPFetcher(timeout, maxBytes, publicIP)
+}
+
+// newHTTPFetcher creates fetcher connecting to addresses allowed by allowIP only.
+func newHTTPFetcher(timeout time.Duration, maxBytes int64, allowIP func(net.IP) bool) *HTTPFetcher {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !allowIP(ip) {
+				return fmt.Errorf("%w: %s", BlockedAddressErr, host)
+			}
+			return nil
+		},
+	}
+
+	transport := &http.Transport{
+		// Proxy would connect to internal network on behalf of fetcher
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &HTTPFetcher{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) > maxRedirects {
+					return TooManyRedirects
+				}
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+				}
+				return nil
+			},
+		},
+		maxBytes: maxBytes,
+	}
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) (*Page, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "mini-reddit-unfurl/1.0")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return nil, NotHTMLErr
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes))
+	if err != nil {
+		return nil, err
+	}
+	return &Page{URL: resp.Request.URL, Body: body}, nil
+}
+
+// Ranges that are not routable on public internet besides ones recognized by net.IP methods
+var reservedNets = []*net.IPNet{
+	mustCIDR("100.64.0.0/10"), // carrier-grade NAT
+	mustCIDR("192.0.0.0/24"),  // IETF protocol assignments
+	mustCIDR("198.18.0.0/15"), // benchmarking
+	mustCIDR("240.0.0.0/4"),   // reserved, includes broadcast
+	mustCIDR("64:ff9b::/96"),  // NAT64 may reach private IPv4 addresses
+}
+
+func publicIP(ip net.IP) bool {
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range reservedNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func mustCIDR(cidr string) *net.IPNet {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
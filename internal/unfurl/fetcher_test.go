@@ -0,0 +1,202 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testTimeout = 2 * time.Second
+
+// newTestFetcher may connect to httptest servers, which listen on loopback.
+func newTestFetcher(maxBytes int64) *HTTPFetcher {
+	return newHTTPFetcher(testTimeout, maxBytes, func(net.IP) bool { return true })
+}
+
+func htmlHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestFetchBlocksNonPublicAddresses(t *testing.T) {
+	srv := httptest.NewServer(htmlHandler("<html></html>"))
+	defer srv.Close()
+
+	_, err := NewHTTPFetcher(testTimeout, 1024).Fetch(context.Background(), srv.URL)
+	if !errors.Is(err, BlockedAddressErr) {
+		t.Fatalf("Fetch() of loopback server error = %v, want %v", err, BlockedAddressErr)
+	}
+}
+
+func TestFetchBlocksRedirectToNonPublicAddress(t *testing.T) {
+	// Every 127.0.0.0/8 address is local on Linux, 127.0.0.2 plays internal host here
+	listener, err := net.Listen("tcp", "127.0.0.2:0")
+	if err != nil {
+		t.Skipf("cannot listen on second loopback address: %v", err)
+	}
+	internal := httptest.NewUnstartedServer(htmlHandler("<html></html>"))
+	internal.Listener.Close()
+	internal.Listener = listener
+	internal.Start()
+	defer internal.Close()
+
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusFound)
+	}))
+	defer public.Close()
+
+	f := newHTTPFetcher(testTimeout, 1024, func(ip net.IP) bool { return ip.Equal(net.IPv4(127, 0, 0, 1)) })
+	_, err = f.Fetch(context.Background(), public.URL)
+	if !errors.Is(err, BlockedAddressErr) {
+		t.Fatalf("Fetch() redirected to blocked address error = %v, want %v", err, BlockedAddressErr)
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.0.0.1", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fc00::1", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a00:1", false},
+	}
+	for _, tt := range tests {
+		if got := publicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("publicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestFetchTruncatesToMaxBytes(t *testing.T) {
+	srv := httptest.NewServer(htmlHandler(strings.Repeat("a", 5000)))
+	defer srv.Close()
+
+	page, err := newTestFetcher(100).Fetch(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(page.Body) != 100 {
+		t.Fatalf("len(Body) = %d, want 100", len(page.Body))
+	}
+}
+
+func TestFetchRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", htmlHandler("<html></html>"))
+	mux.HandleFunc("/hop/", func(w http.ResponseWriter, r *http.Request) {
+		left := strings.TrimPrefix(r.URL.Path, "/hop/")
+		if left == "" {
+			http.Redirect(w, r, "/page", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, "/hop/"+left[1:], http.StatusFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	page, err := newTestFetcher(1024).Fetch(context.Background(), srv.URL+"/hop/"+strings.Repeat("x", maxRedirects-1))
+	if err != nil {
+		t.Fatalf("Fetch() within redirect limit error = %v", err)
+	}
+	if page.URL.Path != "/page" {
+		t.Errorf("page URL = %s, want final url of redirects", page.URL)
+	}
+
+	_, err = newTestFetcher(1024).Fetch(context.Background(), srv.URL+"/hop/"+strings.Repeat("x", maxRedirects))
+	if !errors.Is(err, TooManyRedirects) {
+		t.Fatalf("Fetch() over redirect limit error = %v, want %v", err, TooManyRedirects)
+	}
+}
+
+func TestFetchChecksResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		wantErr bool
+		// Specific error expected, if any
+		target error
+	}{
+		{
+			name:    "html",
+			handler: htmlHandler("<html></html>"),
+		},
+		{
+			name: "xhtml",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/xhtml+xml")
+			},
+		},
+		{
+			name: "image",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "image/png")
+			},
+			wantErr: true,
+			target:  NotHTMLErr,
+		},
+		{
+			name: "missing content type",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header()["Content-Type"] = nil
+			},
+			wantErr: true,
+			target:  NotHTMLErr,
+		},
+		{
+			name: "not found",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			_, err := newTestFetcher(1024).Fetch(context.Background(), srv.URL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.target != nil && !errors.Is(err, tt.target) {
+				t.Fatalf("Fetch() error = %v, want %v", err, tt.target)
+			}
+		})
+	}
+}
+
+func TestFetchTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	f := newHTTPFetcher(50*time.Millisecond, 1024, func(net.IP) bool { return true })
+	if _, err := f.Fetch(context.Background(), srv.URL); err == nil {
+		t.Fatal("Fetch() of slow server succeeded, want timeout")
+	}
+}
@@ -0,0 +1,135 @@
+package unfurl
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Longer texts of pages are cut, previews are meant to be short
+const (
+	maxTitleLen       = 300
+	maxDescriptionLen = 1000
+)
+
+// Metadata is preview of page, fields page does not declare are nil.
+type Metadata struct {
+	Title       *string
+	Description *string
+	// Absolute http or https url of thumbnail image
+	ImageURL *string
+}
+
+// Extract reads metadata from meta tags of page head. OpenGraph tags take precedence over
+// Twitter card tags, which take precedence over title element and description meta tag.
+func Extract(page *Page) *Metadata {
+	found := make(map[string]string)
+	var title string
+	inTitle := false
+
+	z := html.NewTokenizer(bytes.NewReader(page.Body))
+scan:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			break scan
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Body:
+				break scan
+			case atom.Title:
+				inTitle = title == ""
+			case atom.Meta:
+				if !hasAttr {
+					continue
+				}
+				key, content := metaAttrs(z)
+				if _, ok := found[key]; !ok && key != "" && content != "" {
+					found[key] = content
+				}
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Head:
+				break scan
+			case atom.Title:
+				inTitle = false
+			}
+
+		case html.TextToken:
+			if inTitle {
+				title += string(z.Text())
+			}
+		}
+	}
+
+	m := &Metadata{
+		Title:       first(maxTitleLen, found["og:title"], found["twitter:title"], title),
+		Description: first(maxDescriptionLen, found["og:description"], found["twitter:description"], found["description"]),
+	}
+	for _, key := range []string{"og:image:secure_url", "og:image", "og:image:url", "twitter:image", "twitter:image:src"} {
+		if image := imageURL(page.URL, found[key]); image != nil {
+			m.ImageURL = image
+			break
+		}
+	}
+	return m
+}
+
+// metaAttrs returns lowercase property or name of meta tag along with its content.
+func metaAttrs(z *html.Tokenizer) (string, string) {
+	var key, content string
+	for {
+		name, value, more := z.TagAttr()
+		switch string(name) {
+		case "property":
+			key = strings.ToLower(string(value))
+		case "name":
+			if key == "" {
+				key = strings.ToLower(string(value))
+			}
+		case "content":
+			content = string(value)
+		}
+		if !more {
+			return key, content
+		}
+	}
+}
+
+// first returns the first non-blank text with collapsed whitespace, cut to maxLen runes.
+func first(maxLen int, texts ...string) *string {
+	for _, t := range texts {
+		t = strings.Join(strings.Fields(t), " ")
+		if t == "" || !utf8.ValidString(t) {
+			continue
+		}
+		if utf8.RuneCountInString(t) > maxLen {
+			t = string([]rune(t)[:maxLen-1]) + "…"
+		}
+		return &t
+	}
+	return nil
+}
+
+// imageURL resolves image reference against page url, only http and https images are accepted.
+func imageURL(base *url.URL, ref string) *string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil
+	}
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil
+	}
+	image := u.String()
+	return &image
+}
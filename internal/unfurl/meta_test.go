@@ -0,0 +1,133 @@
+package unfurl
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// fetchTestPage serves body as HTML page at /articles/1 and fetches it.
+func fetchTestPage(t *testing.T, body string) (*Page, string) {
+	t.Helper()
+	srv := httptest.NewServer(htmlHandler(body))
+	t.Cleanup(srv.Close)
+
+	page, err := newTestFetcher(1<<20).Fetch(context.Background(), srv.URL+"/articles/1")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	return page, srv.URL
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return *s
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name            string
+		head            string
+		wantTitle       string
+		wantDescription string
+		// Relative to test server, "<nil>" for no image
+		wantImage string
+	}{
+		{
+			name: "opengraph takes precedence",
+			head: `<title>Plain title</title>
+				<meta name="description" content="Plain description">
+				<meta name="twitter:title" content="Twitter title">
+				<meta property="og:title" content="OG title">
+				<meta property="og:description" content="OG description">
+				<meta property="og:image" content="https://cdn.example.com/a.png">`,
+			wantTitle:       "OG title",
+			wantDescription: "OG description",
+			wantImage:       "https://cdn.example.com/a.png",
+		},
+		{
+			name: "twitter card before plain tags",
+			head: `<title>Plain title</title>
+				<meta name="description" content="Plain description">
+				<meta name="twitter:title" content="Twitter title">
+				<meta name="twitter:description" content="Twitter description">
+				<meta name="twitter:image" content="/img/t.png">`,
+			wantTitle:       "Twitter title",
+			wantDescription: "Twitter description",
+			wantImage:       "/img/t.png",
+		},
+		{
+			name:            "plain title and description",
+			head:            `<title>  Plain   &amp; simple  </title><meta name="Description" content="About &quot;it&quot;">`,
+			wantTitle:       "Plain & simple",
+			wantDescription: `About "it"`,
+			wantImage:       "<nil>",
+		},
+		{
+			name:            "relative image resolves against page",
+			head:            `<meta property="og:image" content="thumb.png">`,
+			wantTitle:       "<nil>",
+			wantDescription: "<nil>",
+			wantImage:       "/articles/thumb.png",
+		},
+		{
+			name:            "image with unsupported scheme",
+			head:            `<meta property="og:image" content="javascript:alert(1)"><meta name="twitter:image" content="data:image/png;base64,AA==">`,
+			wantTitle:       "<nil>",
+			wantDescription: "<nil>",
+			wantImage:       "<nil>",
+		},
+		{
+			name:            "blank values are skipped",
+			head:            `<meta property="og:title" content="   "><title>Fallback</title>`,
+			wantTitle:       "Fallback",
+			wantDescription: "<nil>",
+			wantImage:       "<nil>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, base := fetchTestPage(t, "<!doctype html><html><head>"+tt.head+"</head><body><p>text</p></body></html>")
+			m := Extract(page)
+
+			wantImage := tt.wantImage
+			if strings.HasPrefix(wantImage, "/") {
+				wantImage = base + wantImage
+			}
+			if got := deref(m.Title); got != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", got, tt.wantTitle)
+			}
+			if got := deref(m.Description); got != tt.wantDescription {
+				t.Errorf("Description = %q, want %q", got, tt.wantDescription)
+			}
+			if got := deref(m.ImageURL); got != wantImage {
+				t.Errorf("ImageURL = %q, want %q", got, wantImage)
+			}
+		})
+	}
+}
+
+func TestExtractIgnoresBody(t *testing.T) {
+	page, _ := fetchTestPage(t, `<html><head><title>Head</title></head><body><meta property="og:title" content="Body"></body></html>`)
+
+	if got := deref(Extract(page).Title); got != "Head" {
+		t.Fatalf("Title = %q, want meta tags of body ignored", got)
+	}
+}
+
+func TestExtractCutsLongTexts(t *testing.T) {
+	page, _ := fetchTestPage(t, `<head><title>`+strings.Repeat("ж", 2*maxTitleLen)+`</title>`+
+		`<meta name="description" content="`+strings.Repeat("d", 2*maxDescriptionLen)+`"></head>`)
+	m := Extract(page)
+
+	if n := utf8.RuneCountInString(deref(m.Title)); n != maxTitleLen {
+		t.Errorf("title length = %d, want %d", n, maxTitleLen)
+	}
+	if n := utf8.RuneCountInString(deref(m.Description)); n != maxDescriptionLen {
+		t.Errorf("description length = %d, want %d", n, maxDescriptionLen)
+	}
+}
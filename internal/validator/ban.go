@@ -0,0 +1,32 @@
+package validator
+
+import (
+	"errors"
+	"time"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+var ExpiredBanErr = errors.New("ban must expire in the future")
+
+// ValidateBanInput drops empty reason as reason is optional.
+func (val *Validator) ValidateBanInput(in *model.BanInput) error {
+	var v violations
+	if in.Community != nil {
+		checkCommunity(&v, "input.community", in.Community)
+	}
+	in.Reason = val.optionalText(&v, "input.reason", in.Reason, val.ban)
+	if in.ExpiresAt != nil && !in.ExpiresAt.After(time.Now()) {
+		v.add("input.expiresAt", errs.RuleMin, "now", ExpiredBanErr)
+	}
+	return v.err()
+}
+
+// ValidateOptionalCommunityInput checks community if it is given.
+func (val *Validator) ValidateOptionalCommunityInput(community *string) error {
+	if community == nil {
+		return nil
+	}
+	return val.ValidateCommunityInput(community)
+}
@@ -2,49 +2,43 @@ package validator
 
 import (
 	"errors"
-	"strconv"
 
 	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
-	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
-)
-
-const (
-	MaxCommentLen = 2000
 )
 
 var (
-	EmptyCommentErr   = errors.New("comment cannot be empty")
-	TooLongCommentErr = errors.New("comment cannot be longer than " + strconv.Itoa(MaxCommentLen) + " characters")
+	EmptyCommentErr = errors.New("comment cannot be empty")
+	NegativeDepth   = errors.New("depth must be positive")
 )
 
-func validateCommentText(text string) error {
-	if text == "" {
-		return EmptyCommentErr
-	}
-	if len(text) > MaxContentLen {
-		return TooLongCommentErr
+func (val *Validator) ValidateCreateCommentInput(in *model.CreateCommentInput) error {
+	var v violations
+	v.checkID("input.postID", in.PostID)
+	val.checkText(&v, "input.text", &in.Text, val.comment)
+	if in.ParentID != nil {
+		v.checkID("input.parentID", *in.ParentID)
 	}
-	return nil
+	checkIdempotencyKey(&v, "input.idempotencyKey", in.IdempotencyKey)
+	return v.err()
 }
 
-func ValidateCreateCommentInput(in model.CreateCommentInput) error {
-	if _, err := strconv.Atoi(in.PostID); err != nil {
-		return errs.InvalidID
-	}
-	if err := validateCommentText(in.Text); err != nil {
-		return err
-	}
-	if in.ParentID != nil {
-		if _, err := strconv.Atoi(*in.ParentID); err != nil {
-			return errs.InvalidID
-		}
-	}
-	return nil
+func (val *Validator) ValidateUpdateCommentInput(in *model.UpdateCommentInput) error {
+	var v violations
+	v.checkID("input.id", in.ID)
+	val.checkText(&v, "input.text", &in.Text, val.comment)
+	v.checkVersion("input.expectedVersion", in.ExpectedVersion)
+	return v.err()
 }
 
-func ValidateUpdateCommentInput(in model.UpdateCommentInput) error {
-	if _, err := strconv.Atoi(in.ID); err != nil {
-		return errs.InvalidID
-	}
-	return validateCommentText(in.Text)
+func (val *Validator) ValidateCommentsInput(limit, depth int32) error {
+	var v violations
+	v.checkLimit("limit", limit, val.limits.MaxCommentsPerPage)
+	v.checkDepth("depth", depth, val.limits.MaxCommentDepth)
+	return v.err()
+}
+
+func (val *Validator) ValidateParentTreeInput(depth int32) error {
+	var v violations
+	v.checkDepth("depth", depth, val.limits.MaxCommentDepth)
+	return v.err()
 }
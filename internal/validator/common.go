@@ -2,6 +2,7 @@ package validator
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
@@ -12,14 +13,101 @@ var (
 	InvalidVoteValueErr = errors.New("vote value must be 1 or -1")
 	NegativeLimit       = errors.New("limit must be positive")
 	NothingToUpdateErr  = errors.New("at least one field needed to update")
+	InvalidVersionErr   = errors.New("version must be positive")
 )
 
-func ValidateVoteInput(in model.VoteInput) error {
-	if _, err := strconv.Atoi(in.ID); err != nil {
-		return errs.InvalidID
+// Validator checks user input and normalizes its text fields in place.
+type Validator struct {
+	text    TextPolicy
+	limits  Limits
+	title   textRule
+	content textRule
+	comment textRule
+	report  textRule
+	reason  textRule
+	ban     textRule
+	search  textRule
+}
+
+// Limits bound sizes of user content and pages. Text lengths are measured according to TextPolicy.
+type Limits struct {
+	MaxTitleLen        int
+	MaxContentLen      int
+	MaxCommentLen      int
+	MaxReportLen       int
+	MaxPostsPerPage    int32
+	MaxCommentsPerPage int32
+	MaxReportsPerPage  int32
+	MaxModLogPerPage   int32
+	// MaxCommentDepth bounds how many levels of comment tree single field may expand
+	MaxCommentDepth int32
+}
+
+func New(text TextPolicy, limits Limits) *Validator {
+	return &Validator{
+		text:    text,
+		limits:  limits,
+		title:   textRule{name: "post title", maxLen: limits.MaxTitleLen, emptyErr: EmptyTitleErr},
+		content: textRule{name: "post content", maxLen: limits.MaxContentLen, emptyErr: EmptyContentErr},
+		comment: textRule{name: "comment", maxLen: limits.MaxCommentLen, emptyErr: EmptyCommentErr},
+		report:  textRule{name: "report text", maxLen: limits.MaxReportLen},
+		reason:  textRule{name: "removal reason", maxLen: limits.MaxReportLen},
+		ban:     textRule{name: "ban reason", maxLen: limits.MaxReportLen},
+		search:  textRule{name: "search query", maxLen: limits.MaxTitleLen, emptyErr: EmptySearchErr},
+	}
+}
+
+// violations collects every failed rule of single input instead of stopping at the first one.
+type violations []*errs.FieldError
+
+func (v *violations) add(field, rule string, limit any, err error) {
+	*v = append(*v, errs.Field(field, rule, limit, err))
+}
+
+func (v *violations) checkID(field, id string) {
+	if _, err := strconv.Atoi(id); err != nil {
+		*v = append(*v, errs.InvalidIDField(field))
+	}
+}
+
+func (v *violations) checkLimit(field string, limit, maxLimit int32) {
+	if limit < 0 {
+		v.add(field, errs.RuleMin, 0, NegativeLimit)
 	}
+	if limit > maxLimit {
+		v.add(field, errs.RuleMax, maxLimit, fmt.Errorf("limit cannot be greater than %d", maxLimit))
+	}
+}
+
+func (v *violations) checkDepth(field string, depth, maxDepth int32) {
+	if depth < 0 {
+		v.add(field, errs.RuleMin, 0, NegativeDepth)
+	}
+	if depth > maxDepth {
+		v.add(field, errs.RuleMax, maxDepth, fmt.Errorf("depth cannot be greater than %d", maxDepth))
+	}
+}
+
+// checkVersion checks optional expected version of edited content.
+func (v *violations) checkVersion(field string, version *int32) {
+	if version != nil && *version < 1 {
+		v.add(field, errs.RuleMin, 1, InvalidVersionErr)
+	}
+}
+
+// err returns nil if nothing failed.
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return &errs.ValidationError{Fields: v}
+}
+
+func (val *Validator) ValidateVoteInput(in model.VoteInput) error {
+	var v violations
+	v.checkID("input.id", in.ID)
 	if in.Value != 1 && in.Value != -1 {
-		return InvalidVoteValueErr
+		v.add("input.value", errs.RuleOneOf, []int{1, -1}, InvalidVoteValueErr)
 	}
-	return nil
+	return v.err()
 }
@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+var communityName = regexp.MustCompile(`^[a-z0-9_]{3,21}$`)
+
+var InvalidCommunityErr = errors.New("community name must consist of 3 to 21 latin letters, digits or underscores")
+
+// checkCommunity lowercases community name in place, names are case-insensitive.
+func checkCommunity(v *violations, field string, community *string) {
+	*community = strings.ToLower(strings.TrimSpace(*community))
+	if !communityName.MatchString(*community) {
+		v.add(field, errs.RulePattern, communityName.String(), InvalidCommunityErr)
+	}
+}
+
+func (val *Validator) ValidateCommunityInput(community *string) error {
+	var v violations
+	checkCommunity(&v, "community", community)
+	return v.err()
+}
@@ -0,0 +1,20 @@
+package validator
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+// Fits UUIDs and other random tokens clients usually generate
+var idempotencyKey = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+var InvalidIdempotencyKeyErr = errors.New("idempotency key must consist of 1 to 128 latin letters, digits, dashes or underscores")
+
+// checkIdempotencyKey checks optional key of create operation.
+func checkIdempotencyKey(v *violations, field string, key *string) {
+	if key != nil && !idempotencyKey.MatchString(*key) {
+		v.add(field, errs.RulePattern, idempotencyKey.String(), InvalidIdempotencyKeyErr)
+	}
+}
@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/linkurl"
+)
+
+const (
+	maxURLLen = 2048
+	// Longest domain name allowed by DNS
+	maxDomainLen = 253
+)
+
+var (
+	MissingURLErr    = errors.New("link post needs url")
+	UnexpectedURLErr = errors.New("text post cannot have url")
+	EmptyDomainErr   = errors.New("domain cannot be empty")
+)
+
+// checkLinkURL trims url in place, url is required for link posts and not allowed for text ones.
+func checkLinkURL(v *violations, field string, kind model.PostKind, url *string) {
+	if kind != model.PostKindLink {
+		if url != nil {
+			v.add(field, errs.RuleNotAllowed, nil, UnexpectedURLErr)
+		}
+		return
+	}
+	if url == nil {
+		v.add(field, errs.RuleRequired, nil, MissingURLErr)
+		return
+	}
+
+	if len(*url) > maxURLLen {
+		v.add(field, errs.RuleMaxLength, maxURLLen, fmt.Errorf("url cannot be longer than %d characters", maxURLLen))
+		return
+	}
+	link, err := linkurl.Parse(*url)
+	if err != nil {
+		v.add(field, errs.RuleURL, nil, err)
+		return
+	}
+	*url = link.URL
+}
+
+// checkDomain normalizes domain in place the same way as domains of link posts.
+func checkDomain(v *violations, field string, domain *string) {
+	*domain = linkurl.NormalizeDomain(*domain)
+	if *domain == "" {
+		v.add(field, errs.RuleRequired, nil, EmptyDomainErr)
+	}
+	if len(*domain) > maxDomainLen {
+		v.add(field, errs.RuleMaxLength, maxDomainLen, fmt.Errorf("domain cannot be longer than %d characters", maxDomainLen))
+	}
+}
@@ -2,82 +2,68 @@ package validator
 
 import (
 	"errors"
-	"strconv"
 
 	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
 	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
 )
 
-const (
-	MaxTitleLen         = 200
-	MaxContentLen       = 20000
-	MaxPostsLimit int32 = 100
-)
-
 var (
-	EmptyTitleErr     = errors.New("post title cannot be empty")
-	EmptyContentErr   = errors.New("post content cannot be empty")
-	TooLongTitleErr   = errors.New("post title cannot be longer than " + strconv.Itoa(MaxTitleLen) + " characters")
-	TooLongContentErr = errors.New("post content cannot be longer than " + strconv.Itoa(MaxContentLen) + " characters")
-	TooBigPostLimit   = errors.New("post limit cannot be longer than " + strconv.Itoa(int(MaxPostsLimit)))
+	EmptyTitleErr       = errors.New("post title cannot be empty")
+	EmptyContentErr     = errors.New("post content cannot be empty")
+	UnsupportedPostSort = errors.New("posts cannot be sorted by " + string(model.SortOrderBest))
 )
 
-func ValidateCreatePostInput(in model.CreatePostInput) error {
-	if err := validateTitle(in.Title); err != nil {
-		return err
+func (val *Validator) ValidateCreatePostInput(in *model.CreatePostInput) error {
+	var v violations
+	checkCommunity(&v, "input.community", &in.Community)
+	val.checkText(&v, "input.title", &in.Title, val.title)
+	if in.Kind == model.PostKindLink {
+		val.optionalText(&v, "input.content", &in.Content, val.content)
+	} else {
+		val.checkText(&v, "input.content", &in.Content, val.content)
 	}
-	return validateContent(in.Content)
+	checkLinkURL(&v, "input.url", in.Kind, in.URL)
+	checkIdempotencyKey(&v, "input.idempotencyKey", in.IdempotencyKey)
+	return v.err()
 }
 
-func ValidateUpdatePostInput(in model.UpdatePostInput) error {
-	if _, err := strconv.Atoi(in.ID); err != nil {
-		return errs.InvalidID
-	}
+// ValidateUpdatePostInput checks edit of post of given kind, content of link post may be cleared.
+func (val *Validator) ValidateUpdatePostInput(in *model.UpdatePostInput, kind model.PostKind) error {
+	var v violations
+	v.checkID("input.id", in.ID)
 
 	if in.Title == nil && in.Content == nil {
-		return NothingToUpdateErr
+		v.add("input", errs.RuleAnyRequired, []string{"title", "content"}, NothingToUpdateErr)
 	}
-
 	if in.Title != nil {
-		if err := validateTitle(*in.Title); err != nil {
-			return err
-		}
+		val.checkText(&v, "input.title", in.Title, val.title)
 	}
-	if in.Content != nil {
-		if err := validateContent(*in.Content); err != nil {
-			return err
-		}
+	if kind == model.PostKindLink {
+		val.optionalText(&v, "input.content", in.Content, val.content)
+	} else if in.Content != nil {
+		val.checkText(&v, "input.content", in.Content, val.content)
 	}
+	v.checkVersion("input.expectedVersion", in.ExpectedVersion)
 
-	return nil
-}
-
-func ValidatePostsInput(limit int32) error {
-	if limit < 0 {
-		return NegativeLimit
-	}
-	if limit > MaxPostsLimit {
-		return TooBigPostLimit
-	}
-	return nil
+	return v.err()
 }
 
-func validateTitle(title string) error {
-	if title == "" {
-		return EmptyTitleErr
+func (val *Validator) ValidatePostsInput(sort model.SortOrder, limit int32) error {
+	var v violations
+	if sort == model.SortOrderBest {
+		v.add("sort", errs.RuleNotOneOf, []model.SortOrder{model.SortOrderBest}, UnsupportedPostSort)
 	}
-	if len(title) > MaxTitleLen {
-		return TooLongTitleErr
-	}
-	return nil
+	v.checkLimit("limit", limit, val.limits.MaxPostsPerPage)
+	return v.err()
 }
 
-func validateContent(content string) error {
-	if content == "" {
-		return EmptyContentErr
-	}
-	if len(content) > MaxContentLen {
-		return TooLongContentErr
+// ValidatePostsByDomainInput normalizes domain in place.
+func (val *Validator) ValidatePostsByDomainInput(domain *string, sort model.SortOrder, limit int32) error {
+	var v violations
+	checkDomain(&v, "domain", domain)
+	if sort == model.SortOrderBest {
+		v.add("sort", errs.RuleNotOneOf, []model.SortOrder{model.SortOrderBest}, UnsupportedPostSort)
 	}
-	return nil
+	v.checkLimit("limit", limit, val.limits.MaxPostsPerPage)
+	return v.err()
 }
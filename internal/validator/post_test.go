@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+)
+
+func newTestValidator() *Validator {
+	return New(TextPolicy{TrimSpace: true}, Limits{
+		MaxTitleLen:        200,
+		MaxContentLen:      20000,
+		MaxPostsPerPage:    100,
+		MaxCommentsPerPage: 100,
+		MaxCommentDepth:    10,
+	})
+}
+
+func TestValidateUpdatePostInputByKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    model.PostKind
+		content string
+		wantErr bool
+	}{
+		{"text post with content", model.PostKindText, "body", false},
+		{"text post cleared", model.PostKindText, "", true},
+		{"text post with blank content", model.PostKindText, "  ", true},
+		{"link post with content", model.PostKindLink, "body", false},
+		{"link post cleared", model.PostKindLink, "", false},
+		{"link post with blank content", model.PostKindLink, "  ", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := tt.content
+			err := newTestValidator().ValidateUpdatePostInput(&model.UpdatePostInput{ID: "1", Content: &content}, tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateUpdatePostInput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
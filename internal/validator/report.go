@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+)
+
+// ValidateReportInput checks report, empty text is dropped as details are optional.
+func (val *Validator) ValidateReportInput(in *model.ReportInput) error {
+	var v violations
+	v.checkID("input.id", in.ID)
+	in.Text = val.optionalText(&v, "input.text", in.Text, val.report)
+	return v.err()
+}
+
+// ValidateRemoveInput checks removal by moderator, empty reason is dropped as reason is optional.
+func (val *Validator) ValidateRemoveInput(id string, reason **string) error {
+	var v violations
+	v.checkID("id", id)
+	*reason = val.optionalText(&v, "reason", *reason, val.reason)
+	return v.err()
+}
+
+func (val *Validator) ValidateModerationQueueInput(community *string, limit int32) error {
+	var v violations
+	checkCommunity(&v, "community", community)
+	v.checkLimit("limit", limit, val.limits.MaxReportsPerPage)
+	return v.err()
+}
+
+// ValidateModLogInput checks community if it is given.
+func (val *Validator) ValidateModLogInput(community *string, limit int32) error {
+	var v violations
+	if community != nil {
+		checkCommunity(&v, "community", community)
+	}
+	v.checkLimit("limit", limit, val.limits.MaxModLogPerPage)
+	return v.err()
+}
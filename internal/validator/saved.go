@@ -0,0 +1,15 @@
+package validator
+
+import (
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+)
+
+func (val *Validator) ValidateSavedInput(savedType model.SavedType, limit int32) error {
+	var v violations
+	if savedType == model.SavedTypeComment {
+		v.checkLimit("limit", limit, val.limits.MaxCommentsPerPage)
+	} else {
+		v.checkLimit("limit", limit, val.limits.MaxPostsPerPage)
+	}
+	return v.err()
+}
@@ -0,0 +1,20 @@
+package validator
+
+import (
+	"errors"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/graph/model"
+)
+
+var EmptySearchErr = errors.New("search query cannot be empty")
+
+func (val *Validator) ValidateSearchInput(query *string, searchType model.SearchType, limit int32) error {
+	var v violations
+	val.checkText(&v, "query", query, val.search)
+	if searchType == model.SearchTypeComment {
+		v.checkLimit("limit", limit, val.limits.MaxCommentsPerPage)
+	} else {
+		v.checkLimit("limit", limit, val.limits.MaxPostsPerPage)
+	}
+	return v.err()
+}
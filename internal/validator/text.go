@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/trust-me-im-an-engineer/mini-reddit/internal/errs"
+)
+
+var (
+	ControlCharactersErr = errors.New("text cannot contain control characters")
+	InvisibleTextErr     = errors.New("text cannot consist of invisible characters only")
+)
+
+// TextPolicy controls how user-submitted text is normalized and measured.
+type TextPolicy struct {
+	// CountGraphemes measures length in user-perceived characters (grapheme clusters), in runes otherwise
+	CountGraphemes  bool
+	NormalizeNFC    bool
+	TrimSpace       bool
+	RejectInvisible bool
+	RejectControl   bool
+}
+
+// textRule describes limits of single text field.
+type textRule struct {
+	name     string
+	maxLen   int
+	emptyErr error
+}
+
+// normalize rewrites text in place according to policy.
+func (val *Validator) normalize(text *string) {
+	if val.text.NormalizeNFC {
+		*text = norm.NFC.String(*text)
+	}
+	if val.text.TrimSpace {
+		*text = strings.TrimSpace(*text)
+	}
+}
+
+func (val *Validator) length(text string) int {
+	if val.text.CountGraphemes {
+		return uniseg.GraphemeClusterCount(text)
+	}
+	return utf8.RuneCountInString(text)
+}
+
+// checkText normalizes text and records every rule it violates.
+func (val *Validator) checkText(v *violations, field string, text *string, rule textRule) {
+	val.normalize(text)
+
+	if *text == "" {
+		v.add(field, errs.RuleRequired, nil, rule.emptyErr)
+		return
+	}
+	if val.text.RejectInvisible && isInvisible(*text) {
+		v.add(field, errs.RuleVisible, nil, InvisibleTextErr)
+	}
+	if val.text.RejectControl && hasControl(*text) {
+		v.add(field, errs.RuleNoControl, nil, ControlCharactersErr)
+	}
+	if val.length(*text) > rule.maxLen {
+		v.add(field, errs.RuleMaxLength, rule.maxLen, fmt.Errorf("%s cannot be longer than %d characters", rule.name, rule.maxLen))
+	}
+}
+
+// optionalText checks text if it is given, it returns nil for text empty after normalization.
+func (val *Validator) optionalText(v *violations, field string, text *string, rule textRule) *string {
+	if text == nil {
+		return nil
+	}
+	val.normalize(text)
+	if *text == "" {
+		return nil
+	}
+	val.checkText(v, field, text, rule)
+	return text
+}
+
+// hasControl reports control characters except line breaks and tabs.
+func hasControl(text string) bool {
+	return strings.ContainsFunc(text, func(r rune) bool {
+		return unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t'
+	})
+}
+
+// isInvisible reports text rendered as blank: whitespace, zero-width and other format characters, fillers.
+func isInvisible(text string) bool {
+	return !strings.ContainsFunc(text, func(r rune) bool {
+		switch r {
+		case '\u115F', '\u1160', '\u2800', '\u3164', '\uFFA0': // hangul and braille fillers
+			return false
+		}
+		return !unicode.IsSpace(r) && !unicode.Is(unicode.Cf, r) && !unicode.Is(unicode.Mn, r)
+	})
+}
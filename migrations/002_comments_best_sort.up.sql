@@ -0,0 +1,24 @@
+ALTER TABLE comments
+    ADD COLUMN upvotes    INT              NOT NULL DEFAULT 0,
+    ADD COLUMN downvotes  INT              NOT NULL DEFAULT 0,
+    -- Lower bound of Wilson score interval, recalculated by application on every vote
+    ADD COLUMN best_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+UPDATE comments c
+SET upvotes   = (SELECT COUNT(*) FROM comment_votes v WHERE v.comment_id = c.id AND v.value = 1),
+    downvotes = (SELECT COUNT(*) FROM comment_votes v WHERE v.comment_id = c.id AND v.value = -1);
+
+-- Same formula as ranking.Wilson with z for 95% confidence
+UPDATE comments c
+SET best_score = GREATEST(0, (s.p + s.z * s.z / (2 * s.n)
+    - s.z * SQRT((s.p * (1 - s.p) + s.z * s.z / (4 * s.n)) / s.n)) / (1 + s.z * s.z / s.n))
+FROM (SELECT id,
+             upvotes::DOUBLE PRECISION + downvotes            AS n,
+             upvotes::DOUBLE PRECISION / (upvotes + downvotes) AS p,
+             1.959963984540054::DOUBLE PRECISION              AS z
+      FROM comments
+      WHERE upvotes + downvotes > 0) s
+WHERE c.id = s.id;
+
+CREATE INDEX comments_post_id_best_score_id_idx ON comments (post_id, best_score DESC, id ASC);
+CREATE INDEX comments_parent_id_best_score_id_idx ON comments (parent_id, best_score DESC, id ASC);
@@ -0,0 +1,30 @@
+ALTER TABLE posts
+    ADD COLUMN upvotes        INT NOT NULL DEFAULT 0,
+    ADD COLUMN downvotes      INT NOT NULL DEFAULT 0,
+    ADD COLUMN comments_count INT NOT NULL DEFAULT 0;
+
+UPDATE posts p
+SET upvotes        = (SELECT COUNT(*) FROM post_votes v WHERE v.post_id = p.id AND v.value = 1),
+    downvotes      = (SELECT COUNT(*) FROM post_votes v WHERE v.post_id = p.id AND v.value = -1),
+    comments_count = (SELECT COUNT(*) FROM comments c WHERE c.post_id = p.id AND NOT c.deleted);
+
+
+CREATE OR REPLACE FUNCTION maintain_post_comments_count()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE posts SET comments_count = comments_count + 1 WHERE id = NEW.post_id;
+    ELSIF NEW.deleted AND NOT OLD.deleted THEN
+        UPDATE posts SET comments_count = comments_count - 1 WHERE id = NEW.post_id;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER maintain_post_comments_count_trigger
+    AFTER INSERT OR UPDATE OF deleted
+    ON comments
+    FOR EACH ROW
+EXECUTE FUNCTION maintain_post_comments_count();
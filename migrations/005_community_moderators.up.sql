@@ -0,0 +1,12 @@
+ALTER TABLE posts
+    ADD COLUMN community TEXT NOT NULL DEFAULT 'general';
+
+CREATE TABLE IF NOT EXISTS community_moderators
+(
+    community  TEXT        NOT NULL,
+    user_id    uuid        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (community, user_id)
+);
+
+CREATE INDEX posts_community_idx ON posts (community);
@@ -0,0 +1,21 @@
+-- Reports do not reference posts and comments, so that moderation history outlives removed content
+CREATE TABLE IF NOT EXISTS reports
+(
+    id          BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    post_id     BIGINT      NOT NULL,
+    comment_id  BIGINT,
+    community   TEXT        NOT NULL,
+    reporter_id uuid        NOT NULL,
+    reason      TEXT        NOT NULL,
+    "text"      TEXT,
+    status      TEXT        NOT NULL DEFAULT 'OPEN',
+    created_at  timestamptz NOT NULL DEFAULT NOW(),
+    resolved_by uuid,
+    resolved_at timestamptz
+);
+
+-- Single report per reporter and content
+CREATE UNIQUE INDEX reports_post_id_reporter_id_idx ON reports (post_id, reporter_id) WHERE comment_id IS NULL;
+CREATE UNIQUE INDEX reports_comment_id_reporter_id_idx ON reports (comment_id, reporter_id) WHERE comment_id IS NOT NULL;
+
+CREATE INDEX reports_community_status_id_idx ON reports (community, status, id);
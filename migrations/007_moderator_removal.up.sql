@@ -0,0 +1,40 @@
+ALTER TABLE posts
+    ADD COLUMN removed_by     uuid,
+    ADD COLUMN removed_reason TEXT,
+    ADD COLUMN removed_at     timestamptz;
+
+ALTER TABLE comments
+    ADD COLUMN removed_by     uuid,
+    ADD COLUMN removed_reason TEXT,
+    ADD COLUMN removed_at     timestamptz;
+
+
+-- Comments removed by moderator are not counted, same as deleted ones
+CREATE OR REPLACE FUNCTION maintain_post_comments_count()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    was_visible BOOLEAN := FALSE;
+    is_visible  BOOLEAN := NOT NEW.deleted AND NEW.removed_at IS NULL;
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        was_visible := NOT OLD.deleted AND OLD.removed_at IS NULL;
+    END IF;
+
+    IF is_visible AND NOT was_visible THEN
+        UPDATE posts SET comments_count = comments_count + 1 WHERE id = NEW.post_id;
+    ELSIF was_visible AND NOT is_visible THEN
+        UPDATE posts SET comments_count = comments_count - 1 WHERE id = NEW.post_id;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER maintain_post_comments_count_trigger ON comments;
+
+CREATE TRIGGER maintain_post_comments_count_trigger
+    AFTER INSERT OR UPDATE OF deleted, removed_at
+    ON comments
+    FOR EACH ROW
+EXECUTE FUNCTION maintain_post_comments_count();
@@ -0,0 +1,33 @@
+ALTER TABLE posts
+    ADD COLUMN deleted    BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN deleted_at timestamptz;
+
+-- Purge job scans deleted posts only
+CREATE INDEX posts_deleted_at_idx ON posts (deleted_at) WHERE deleted;
+
+
+CREATE OR REPLACE FUNCTION check_post_comments_restriction()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    is_restricted BOOLEAN;
+    is_deleted    BOOLEAN;
+BEGIN
+    SELECT comments_restricted, deleted
+    INTO is_restricted, is_deleted
+    FROM posts
+    WHERE id = NEW.post_id;
+
+    IF is_deleted THEN
+        RAISE EXCEPTION 'Post % is deleted', NEW.post_id
+            USING ERRCODE = '90003';
+    END IF;
+
+    IF is_restricted THEN
+        RAISE EXCEPTION 'Comments restricted for post %', NEW.post_id
+            USING ERRCODE = '90001';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
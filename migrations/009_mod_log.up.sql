@@ -0,0 +1,32 @@
+-- Audit log of moderation and administrative actions, it does not reference its targets
+-- so that history outlives purged content
+CREATE TABLE IF NOT EXISTS mod_log
+(
+    id          BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    community   TEXT        NOT NULL,
+    actor_id    uuid        NOT NULL,
+    action      TEXT        NOT NULL,
+    target_type TEXT        NOT NULL,
+    target_id   TEXT        NOT NULL,
+    before      jsonb,
+    after       jsonb,
+    created_at  timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX mod_log_community_id_idx ON mod_log (community, id DESC);
+CREATE INDEX mod_log_community_actor_id_id_idx ON mod_log (community, actor_id, id DESC);
+
+-- Log is append-only
+CREATE OR REPLACE FUNCTION forbid_mod_log_change()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'mod_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER forbid_mod_log_change
+    BEFORE UPDATE OR DELETE
+    ON mod_log
+    FOR EACH ROW
+EXECUTE FUNCTION forbid_mod_log_change();
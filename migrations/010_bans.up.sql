@@ -0,0 +1,14 @@
+-- Bans without community are global
+CREATE TABLE IF NOT EXISTS bans
+(
+    community  TEXT,
+    user_id    uuid        NOT NULL,
+    reason     TEXT,
+    banned_by  uuid        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    expires_at timestamptz
+);
+
+-- Single ban per user and community
+CREATE UNIQUE INDEX bans_community_user_id_idx ON bans (COALESCE(community, ''), user_id);
+CREATE INDEX bans_user_id_idx ON bans (user_id);
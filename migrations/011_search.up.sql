@@ -0,0 +1,65 @@
+-- Search documents live apart from content, so that rows of posts and comments stay as they are
+CREATE TABLE IF NOT EXISTS post_documents
+(
+    post_id  BIGINT PRIMARY KEY REFERENCES posts (id) ON DELETE CASCADE,
+    document tsvector NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS comment_documents
+(
+    comment_id BIGINT PRIMARY KEY REFERENCES comments (id) ON DELETE CASCADE,
+    document   tsvector NOT NULL
+);
+
+CREATE INDEX post_documents_document_idx ON post_documents USING GIN (document);
+CREATE INDEX comment_documents_document_idx ON comment_documents USING GIN (document);
+
+
+-- Title weighs more than content
+CREATE OR REPLACE FUNCTION maintain_post_document()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    INSERT INTO post_documents (post_id, document)
+    VALUES (NEW.id, setweight(to_tsvector('english', NEW.title), 'A') ||
+                    setweight(to_tsvector('english', NEW.content), 'B'))
+    ON CONFLICT (post_id) DO UPDATE SET document = EXCLUDED.document;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER maintain_post_document
+    AFTER INSERT OR UPDATE OF title, content
+    ON posts
+    FOR EACH ROW
+EXECUTE FUNCTION maintain_post_document();
+
+
+-- Deleted comment has no text and matches nothing
+CREATE OR REPLACE FUNCTION maintain_comment_document()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+    INSERT INTO comment_documents (comment_id, document)
+    VALUES (NEW.id, to_tsvector('english', COALESCE(NEW.text, '')))
+    ON CONFLICT (comment_id) DO UPDATE SET document = EXCLUDED.document;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER maintain_comment_document
+    AFTER INSERT OR UPDATE OF text
+    ON comments
+    FOR EACH ROW
+EXECUTE FUNCTION maintain_comment_document();
+
+
+INSERT INTO post_documents (post_id, document)
+SELECT id, setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', content), 'B')
+FROM posts
+ON CONFLICT DO NOTHING;
+
+INSERT INTO comment_documents (comment_id, document)
+SELECT id, to_tsvector('english', COALESCE(text, ''))
+FROM comments
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,19 @@
+-- Saved items go away along with purged posts and comments
+CREATE TABLE IF NOT EXISTS saved_posts
+(
+    user_id  uuid        NOT NULL,
+    post_id  BIGINT      NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    saved_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE TABLE IF NOT EXISTS saved_comments
+(
+    user_id    uuid        NOT NULL,
+    comment_id BIGINT      NOT NULL REFERENCES comments (id) ON DELETE CASCADE,
+    saved_at   timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, comment_id)
+);
+
+CREATE INDEX saved_posts_user_id_saved_at_idx ON saved_posts (user_id, saved_at DESC, post_id ASC);
+CREATE INDEX saved_comments_user_id_saved_at_idx ON saved_comments (user_id, saved_at DESC, comment_id ASC);
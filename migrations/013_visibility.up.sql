@@ -0,0 +1,18 @@
+-- Posts users chose not to see in feeds
+CREATE TABLE IF NOT EXISTS hidden_posts
+(
+    user_id   uuid        NOT NULL,
+    post_id   BIGINT      NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    hidden_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+-- Posts and comments of blocked users are skipped in listings for user who blocked them
+CREATE TABLE IF NOT EXISTS blocked_users
+(
+    user_id    uuid        NOT NULL,
+    blocked_id uuid        NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, blocked_id),
+    CHECK (user_id <> blocked_id)
+);
@@ -0,0 +1,28 @@
+ALTER TABLE posts
+    ADD COLUMN edited_at timestamptz;
+
+ALTER TABLE comments
+    ADD COLUMN edited_at timestamptz;
+
+-- Versions of posts and comments replaced by edits, edited_at is when version was replaced
+CREATE TABLE IF NOT EXISTS post_revisions
+(
+    id        BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    post_id   BIGINT      NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    title     TEXT        NOT NULL,
+    content   TEXT        NOT NULL,
+    editor_id uuid        NOT NULL,
+    edited_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS comment_revisions
+(
+    id         BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    comment_id BIGINT      NOT NULL REFERENCES comments (id) ON DELETE CASCADE,
+    "text"     TEXT        NOT NULL,
+    editor_id  uuid        NOT NULL,
+    edited_at  timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX post_revisions_post_id_id_idx ON post_revisions (post_id, id);
+CREATE INDEX comment_revisions_comment_id_id_idx ON comment_revisions (comment_id, id);
@@ -0,0 +1,17 @@
+-- Version grows with every edit, edits carrying stale expected version are rejected
+ALTER TABLE posts
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE comments
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+-- Version of already edited content follows its revisions
+UPDATE posts p
+SET version = 1 + r.count
+FROM (SELECT post_id, COUNT(*) AS count FROM post_revisions GROUP BY post_id) r
+WHERE r.post_id = p.id;
+
+UPDATE comments c
+SET version = 1 + r.count
+FROM (SELECT comment_id, COUNT(*) AS count FROM comment_revisions GROUP BY comment_id) r
+WHERE r.comment_id = c.id;
@@ -0,0 +1,15 @@
+-- Results of create operations by keys clients send to retry them safely.
+-- Result id is NULL while operation is in progress
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    user_id     uuid        NOT NULL,
+    operation   TEXT        NOT NULL,
+    key         TEXT        NOT NULL,
+    fingerprint TEXT        NOT NULL,
+    result_id   BIGINT,
+    created_at  timestamptz NOT NULL DEFAULT NOW(),
+    expires_at  timestamptz NOT NULL,
+    PRIMARY KEY (user_id, operation, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
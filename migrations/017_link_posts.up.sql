@@ -0,0 +1,10 @@
+-- Link posts point to url, domain and normalized url are derived from it
+ALTER TABLE posts
+    ADD COLUMN kind           TEXT NOT NULL DEFAULT 'TEXT',
+    ADD COLUMN url            TEXT,
+    ADD COLUMN domain         TEXT,
+    ADD COLUMN normalized_url TEXT,
+    ADD CONSTRAINT posts_link_url_check CHECK ((kind = 'LINK') = (url IS NOT NULL));
+
+CREATE INDEX posts_domain_created_at_idx ON posts (domain, created_at DESC, id ASC) WHERE domain IS NOT NULL;
+CREATE INDEX posts_community_normalized_url_idx ON posts (community, normalized_url, created_at DESC) WHERE normalized_url IS NOT NULL;
@@ -0,0 +1,10 @@
+-- Previews of linked pages, shared by posts linking the same page
+CREATE TABLE IF NOT EXISTS link_previews
+(
+    normalized_url TEXT PRIMARY KEY,
+    status         TEXT        NOT NULL,
+    title          TEXT,
+    description    TEXT,
+    image_url      TEXT,
+    fetched_at     timestamptz NOT NULL
+);
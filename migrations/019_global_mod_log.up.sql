@@ -0,0 +1,5 @@
+-- Global actions of admins, such as global bans, are logged without community
+ALTER TABLE mod_log
+    ALTER COLUMN community DROP NOT NULL;
+
+CREATE INDEX mod_log_global_id_idx ON mod_log (id DESC) WHERE community IS NULL;